@@ -0,0 +1,21 @@
+package decodeoptions
+
+// Options holds the settings that control how a LinkedMap is decoded.
+type Options struct {
+	// Nested determines whether objects nested within values of type any are
+	// decoded as LinkedMaps, rather than Go maps.
+	Nested bool
+}
+
+// Option is a function that modifies an Options instance.
+type Option func(*Options)
+
+// Combine builds an Options instance by applying each of opts in turn to the
+// default settings.
+func Combine(opts []Option) Options {
+	var result Options
+	for _, opt := range opts {
+		opt(&result)
+	}
+	return result
+}
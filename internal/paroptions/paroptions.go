@@ -0,0 +1,29 @@
+package paroptions
+
+import "runtime"
+
+// Options holds the settings that control how work is divided between
+// parallel goroutines.
+type Options struct {
+	// Threshold is the minimum number of elements beyond which work will be
+	// split between multiple goroutines.
+	Threshold int
+	// MaxCpu is the maximum number of goroutines used to perform the work.
+	MaxCpu int
+	// Ordered determines whether results must be produced in the same order as
+	// their corresponding inputs.
+	Ordered bool
+}
+
+// Option is a function that modifies an Options instance.
+type Option func(*Options)
+
+// Combine builds an Options instance by applying each of opts in turn to the
+// default settings.
+func Combine(opts []Option) Options {
+	result := Options{Threshold: 100000, MaxCpu: runtime.NumCPU(), Ordered: true}
+	for _, opt := range opts {
+		opt(&result)
+	}
+	return result
+}
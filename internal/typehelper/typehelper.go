@@ -0,0 +1,8 @@
+package typehelper
+
+// IsAny returns true if V is the empty interface type.
+func IsAny[V any]() bool {
+	var zero V
+	_, ok := any(&zero).(*any)
+	return ok
+}
@@ -1,6 +1,7 @@
 package iterator
 
 import (
+	"context"
 	"fmt"
 	"iter"
 
@@ -9,20 +10,30 @@ import (
 )
 
 // A core iterator that obtains values (or an error) from
-// a channel
+// a channel. The generator is stopped by closing done rather than the
+// channel, so the channel is only ever closed by the generator goroutine.
 type genIter[T any] struct {
 	source chan T
+	done   chan struct{}
 	value  T
 }
 
-func newGenIter[T any](source chan T) *genIter[T] {
-	return &genIter[T]{source: source}
+func newGenIter[T any](source chan T, done chan struct{}) *genIter[T] {
+	return &genIter[T]{source: source, done: done}
 }
 
 func (pi *genIter[T]) Next() bool {
-	var ok bool
-	pi.value, ok = <-pi.source
-	return ok
+	if isDone(pi.done) {
+		// Discard any values left in a buffered channel
+		return false
+	}
+	select {
+	case value, ok := <-pi.source:
+		pi.value = value
+		return ok
+	case <-pi.done:
+		return false
+	}
 }
 
 func (pi *genIter[T]) Value() T {
@@ -34,12 +45,22 @@ func (pi *genIter[T]) Chan() <-chan T {
 }
 
 func (pi *genIter[T]) Abort() {
-	safeClose(pi.source)
+	safeClose(pi.done)
+}
+
+// isDone returns true if the done channel of a generator has been closed.
+func isDone(done <-chan struct{}) bool {
+	select {
+	case <-done:
+		return true
+	default:
+		return false
+	}
 }
 
 // Reset is the same as abort for this iterator
 func (pi *genIter[T]) Reset() {
-	safeClose(pi.source)
+	pi.Abort()
 }
 
 func (pi *genIter[T]) Size() IteratorSize {
@@ -62,16 +83,82 @@ type AbortGenerator struct{}
 // function. Values from the function can be yielded to the generator
 // via the Yield method (or an error via the YieldError method).
 type Consumer[T any] struct {
-	sink chan T
+	sink  chan T
+	done  <-chan struct{}
+	ctx   context.Context
+	batch *batchSink[T]
+}
+
+// batchSink accumulates yielded values into slices which are sent over a
+// channel once full.
+type batchSink[T any] struct {
+	sink chan []T
+	buf  []T
+	size int
 }
 
 // Yield yields the next value to the generator
 func (y Consumer[T]) Yield(t T) {
-	if !safeSend(y.sink, t) {
+	if b := y.batch; b != nil {
+		b.buf = append(b.buf, t)
+		if len(b.buf) == b.size {
+			if !guardedSend(y.done, b.sink, b.buf) {
+				panic(AbortGenerator{})
+			}
+			b.buf = make([]T, 0, b.size)
+		}
+		return
+	}
+	if !guardedSend(y.done, y.sink, t) {
 		panic(AbortGenerator{})
 	}
 }
 
+// guardedSend sends a value over a channel unless done is closed, either
+// beforehand or while waiting for the receiver, and returns whether the value
+// was sent.
+func guardedSend[T any](done <-chan struct{}, ch chan<- T, t T) bool {
+	if isDone(done) {
+		return false
+	}
+	select {
+	case ch <- t:
+		return true
+	case <-done:
+		return false
+	}
+}
+
+// flush sends any partially filled batch. It is called once the generator has
+// finished, and so does not panic if the send fails.
+func (y Consumer[T]) flush() {
+	if b := y.batch; b != nil && len(b.buf) > 0 {
+		guardedSend(y.done, b.sink, b.buf)
+		b.buf = nil
+	}
+}
+
+// close closes the channel over which values are sent.
+func (y Consumer[T]) close() {
+	if y.batch != nil {
+		close(y.batch.sink)
+	} else {
+		close(y.sink)
+	}
+}
+
+// Context returns the context of the generator. For generators created by
+// [GenerateCtx] or [GenerateResultsCtx], the context is done once the context
+// passed to those functions is done, or the iterator is aborted. A generator
+// that may block for long periods without yielding a value should use it to
+// return early. For other generators, the context is never done.
+func (y Consumer[T]) Context() context.Context {
+	if y.ctx == nil {
+		return context.Background()
+	}
+	return y.ctx
+}
+
 // ResultConsumer is a variation on `Consumer` which is used to yield only result types. It adds
 // dedicated methods to yield non-error values and errors.
 type ResultConsumer[T any] Consumer[result.Result[T]]
@@ -91,6 +178,11 @@ func (yr *ResultConsumer[T]) YieldError(err error) {
 	yr.Yield(result.Error[T](err))
 }
 
+// Context returns the context of the generator; see [Consumer.Context].
+func (yr *ResultConsumer[T]) Context() context.Context {
+	return (*Consumer[result.Result[T]])(yr).Context()
+}
+
 // GeneratorPanic is an error type indicating that a generator iterator function has panicked
 type GeneratorPanic struct {
 	panic any
@@ -112,7 +204,7 @@ func (pp GeneratorPanic) Unwrap() error {
 type Generator[T any] func(Consumer[T])
 
 func runGenerator[T any](c Consumer[T], activity Generator[T]) {
-	defer safeClose(c.sink)
+	defer c.close()
 	defer func() {
 		if p := recover(); p != nil {
 			if _, abort := p.(AbortGenerator); !abort {
@@ -121,6 +213,7 @@ func runGenerator[T any](c Consumer[T], activity Generator[T]) {
 		}
 	}()
 	activity(c)
+	c.flush()
 }
 
 func safeClose[T any](ch chan T) (ok bool) {
@@ -148,7 +241,9 @@ func safeSend[T any](ch chan<- T, val T) (ok bool) {
 type ResultGenerator[T any] func(ResultConsumer[T]) error
 
 func runResultGenerator[T any](c ResultConsumer[T], activity ResultGenerator[T]) {
-	defer safeClose(c.sink)
+	consumer := Consumer[result.Result[T]](c)
+	defer consumer.close()
+	defer consumer.flush()
 	defer func() {
 		if p := recover(); p != nil {
 			if _, abort := p.(AbortGenerator); !abort {
@@ -160,30 +255,153 @@ func runResultGenerator[T any](c ResultConsumer[T], activity ResultGenerator[T])
 	Check(activity(c))
 }
 
+// genOptions holds the options that control the channel used by a generator.
+type genOptions struct {
+	buffer   int
+	prefetch int
+	batch    int
+}
+
+// GenOption is an option that controls the channel over which a generator
+// function passes values to its iterator, as created by [Generate] or
+// [GenerateResults].
+type GenOption func(*genOptions)
+
+// GenBuffer is an option that sets the capacity of the channel between a
+// generator and its iterator. The capacity is measured in values or, when
+// [GenBatch] is in effect, in batches of values. A generator may run ahead of
+// the consumer of its iterator until the channel is full, at which point it
+// blocks. Defaults to 0, an unbuffered channel.
+func GenBuffer(capacity int) GenOption {
+	return func(o *genOptions) { o.buffer, o.prefetch = capacity, 0 }
+}
+
+// GenPrefetch is an option that sets the number of values a generator may
+// produce ahead of the consumer of its iterator before it blocks. It is an
+// alternative to [GenBuffer] that takes account of the batch size set by
+// [GenBatch]; the channel capacity is set to the number of batches required to
+// hold depth values. Where both options are supplied, the last one takes
+// effect.
+func GenPrefetch(depth int) GenOption {
+	return func(o *genOptions) { o.prefetch, o.buffer = depth, 0 }
+}
+
+// GenBatch is an option that causes the values yielded by a generator to be
+// sent to its iterator in slices of size values, reducing the cost of passing
+// each value between goroutines. A batch is only sent when it is full, or when
+// the generator returns, so values may take longer to reach the consumer.
+// Values less than 2 disable batching, which is the default.
+func GenBatch(size int) GenOption {
+	return func(o *genOptions) { o.batch = size }
+}
+
+func combineGenOptions(opts []GenOption) genOptions {
+	var o genOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.buffer < 0 {
+		o.buffer = 0
+	}
+	if o.prefetch > 0 {
+		o.buffer = ceilDiv(o.prefetch, max(o.batch, 1))
+	}
+	return o
+}
+
+// genBatchIter is a core iterator that obtains values in batches from a
+// channel.
+type genBatchIter[T any] struct {
+	source chan []T
+	done   chan struct{}
+	batch  []T
+	value  T
+}
+
+func (bi *genBatchIter[T]) Next() bool {
+	if isDone(bi.done) {
+		return false
+	}
+	for len(bi.batch) == 0 {
+		select {
+		case batch, ok := <-bi.source:
+			if !ok {
+				return false
+			}
+			bi.batch = batch
+		case <-bi.done:
+			return false
+		}
+	}
+	bi.value, bi.batch = bi.batch[0], bi.batch[1:]
+	return true
+}
+
+func (bi *genBatchIter[T]) Value() T {
+	return bi.value
+}
+
+func (bi *genBatchIter[T]) Abort() {
+	safeClose(bi.done)
+}
+
+// Reset is the same as abort for this iterator
+func (bi *genBatchIter[T]) Reset() {
+	bi.Abort()
+}
+
+func (bi *genBatchIter[T]) Size() IteratorSize {
+	return NewSizeUnknown()
+}
+
+func (bi *genBatchIter[T]) Seq() iter.Seq[T] {
+	return Seq(bi)
+}
+
+func (bi *genBatchIter[T]) SeqOK() bool {
+	return false
+}
+
+// newGenerated creates the consumer passed to a generator function, and the
+// core iterator over the values it yields, according to the options supplied.
+func newGenerated[T any](opts []GenOption) (Consumer[T], CoreIterator[T]) {
+	o := combineGenOptions(opts)
+	done := make(chan struct{})
+	if o.batch > 1 {
+		ch := make(chan []T, o.buffer)
+		return Consumer[T]{done: done, batch: &batchSink[T]{sink: ch, size: o.batch, buf: make([]T, 0, o.batch)}}, &genBatchIter[T]{source: ch, done: done}
+	}
+	ch := make(chan T, o.buffer)
+	return Consumer[T]{sink: ch, done: done}, newGenIter(ch, done)
+}
+
 /*
 Generate creates an Iterator from a Generator function. A Consumer is created and passed to the function.
 The function is run in a separate goroutine, and its yielded values are sent over a channel
 to the iterator where they may be consumed in an iterative way by calls to Next() and Value().
 Alternatively, the channel itself is available via the Chan() method.
-A call to Abort() will cause the channel to close and no further elements will be produced by
-Next() or a read of the channel. Any attempt to subsequently yield a value in the generator
-will cause it to terminate, via an AbortGenerator panic.
+A call to Abort() will cause no further elements to be produced by Next(). Abort() does not
+itself close the channel returned by Chan(); the channel is closed only once the generator
+returns, which it will do when its next attempt to yield a value fails with an AbortGenerator
+panic.
+
+By default the channel is unbuffered, so each value is handed over from the generator to the
+consumer individually. The [GenBuffer], [GenPrefetch] and [GenBatch] options may be supplied
+to allow the generator to run ahead of the consumer, and to pass values in batches.
 */
-func Generate[T any](generator Generator[T]) Iterator[T] {
-	ch := make(chan T)
-	yield := Consumer[T]{ch}
+func Generate[T any](generator Generator[T], opts ...GenOption) Iterator[T] {
+	yield, itr := newGenerated[T](opts)
 	go runGenerator(yield, generator)
-	return NewDefaultIterator(newGenIter(ch))
+	return NewDefaultIterator(itr)
 }
 
 // GenerateResults is a variation on Generate that produces an iterator of result types. If the
 // generator function panics, an error result of type GeneratorPanic is produced prior to closing
-// the consumer channel.
-func GenerateResults[T any](generator ResultGenerator[T]) Iterator[result.Result[T]] {
-	ch := make(chan result.Result[T])
-	yield := ResultConsumer[T](Consumer[result.Result[T]]{ch})
-	go runResultGenerator(yield, generator)
-	return NewDefaultIterator(newGenIter(ch))
+// the consumer channel. The same options as for [Generate] may be supplied.
+func GenerateResults[T any](generator ResultGenerator[T], opts ...GenOption) Iterator[result.Result[T]] {
+	yield, itr := newGenerated[result.Result[T]](opts)
+	go runResultGenerator(ResultConsumer[T](yield), generator)
+	return NewDefaultIterator(itr)
 }
 
 // Chan takes a CoreIterator and produces a channel yielding
@@ -0,0 +1,305 @@
+package iterator
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"time"
+)
+
+var ErrInvalidChunkSize = errors.New("invalid chunk size")
+
+// ceilDiv divides a size by n, rounding up.
+func ceilDiv(size, n int) int {
+	return (size + n - 1) / n
+}
+
+type chunkIter[T any] struct {
+	base  CoreIterator[T]
+	n     int
+	value []T
+}
+
+func (ci *chunkIter[T]) Next() bool {
+	chunk := make([]T, 0, ci.n)
+	for len(chunk) < ci.n && ci.base.Next() {
+		chunk = append(chunk, ci.base.Value())
+	}
+	if len(chunk) == 0 {
+		return false
+	}
+	ci.value = chunk
+	return true
+}
+
+func (ci *chunkIter[T]) Value() []T {
+	return ci.value
+}
+
+func (ci *chunkIter[T]) Abort() {
+	ci.base.Abort()
+}
+
+func (ci *chunkIter[T]) Reset() {
+	ci.base.Reset()
+}
+
+func (ci *chunkIter[T]) Size() IteratorSize {
+	size := ci.base.Size()
+	switch size.Type {
+	case SizeKnown:
+		return NewSize(ceilDiv(size.Size, ci.n))
+	case SizeAtMost:
+		return NewSizeMax(ceilDiv(size.Size, ci.n))
+	default:
+		return size
+	}
+}
+
+func (ci *chunkIter[T]) SeqOK() bool { return ci.base.SeqOK() }
+
+func (ci *chunkIter[T]) Seq() iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		chunk := make([]T, 0, ci.n)
+		for v := range ci.base.Seq() {
+			chunk = append(chunk, v)
+			if len(chunk) == ci.n {
+				if !yield(chunk) {
+					return
+				}
+				chunk = make([]T, 0, ci.n)
+			}
+		}
+		if len(chunk) > 0 {
+			yield(chunk)
+		}
+	}
+}
+
+type windowIter[T any] struct {
+	base      CoreIterator[T]
+	n, step   int
+	need      int
+	window    []T
+	value     []T
+	exhausted bool
+}
+
+func (wi *windowIter[T]) Next() bool {
+	if wi.exhausted {
+		return false
+	}
+	for ; wi.need > 0; wi.need-- {
+		if !wi.base.Next() {
+			wi.exhausted = true
+			return false
+		}
+		if wi.need <= wi.n {
+			wi.window = append(wi.window, wi.base.Value())
+		}
+	}
+	wi.value = append(make([]T, 0, wi.n), wi.window...)
+	wi.window = wi.window[min(wi.step, wi.n):]
+	wi.need = wi.step
+	return true
+}
+
+func (wi *windowIter[T]) Value() []T {
+	return wi.value
+}
+
+func (wi *windowIter[T]) Abort() {
+	wi.exhausted = true
+	wi.base.Abort()
+}
+
+func (wi *windowIter[T]) Reset() {
+	wi.base.Reset()
+	wi.exhausted = false
+	wi.window = nil
+	wi.need = wi.n
+}
+
+func (wi *windowIter[T]) windows(size int) int {
+	if size < wi.need {
+		return 0
+	}
+	return (size-wi.need)/wi.step + 1
+}
+
+func (wi *windowIter[T]) Size() IteratorSize {
+	if wi.exhausted {
+		return NewSize(0)
+	}
+	size := wi.base.Size()
+	switch size.Type {
+	case SizeKnown:
+		return NewSize(wi.windows(size.Size))
+	case SizeAtMost:
+		return NewSizeMax(wi.windows(size.Size))
+	default:
+		return size
+	}
+}
+
+func (wi *windowIter[T]) SeqOK() bool { return false }
+
+func (wi *windowIter[T]) Seq() iter.Seq[[]T] {
+	return Seq(wi)
+}
+
+// batchIter is a channel based iterator over batches of elements, which keeps
+// track of the number of elements received so that it can report its size.
+// The source iterator is consumed by a feeder goroutine, which closes fed
+// once it has finished with the source.
+type batchIter[T any] struct {
+	*genIter[[]T]
+	fed      chan struct{}
+	initial  IteratorSize
+	received int
+	finished bool
+}
+
+func (bi *batchIter[T]) Next() bool {
+	if bi.genIter.Next() {
+		bi.received += len(bi.value)
+		return true
+	}
+	bi.finished = true
+	return false
+}
+
+// Abort stops the iterator and waits for the feeder goroutine to abort the
+// source iterator.
+func (bi *batchIter[T]) Abort() {
+	bi.finished = true
+	bi.genIter.Abort()
+	<-bi.fed
+}
+
+// Reset is the same as abort for this iterator
+func (bi *batchIter[T]) Reset() {
+	bi.Abort()
+}
+
+func (bi *batchIter[T]) Size() IteratorSize {
+	if bi.finished {
+		return NewSize(0)
+	}
+	switch bi.initial.Type {
+	case SizeKnown, SizeAtMost:
+		return NewSizeMax(bi.initial.Size - bi.received)
+	default:
+		return bi.initial
+	}
+}
+
+func (bi *batchIter[T]) Seq() iter.Seq[[]T] {
+	return Seq(bi)
+}
+
+// Chunk builds an iterator that yields the elements of an iterator in slices
+// of n elements. The final slice may hold fewer than n elements if the number
+// of elements is not a multiple of n. Each slice is newly allocated, and so may
+// be retained by the caller. If n is less than 1, this function will panic with
+// [ErrInvalidChunkSize].
+func Chunk[T any](itr CoreIterator[T], n int) Iterator[[]T] {
+	if n < 1 {
+		panic(fmt.Errorf("%w: %d", ErrInvalidChunkSize, n))
+	}
+	return NewDefaultIterator(&chunkIter[T]{base: itr, n: n})
+}
+
+// Window builds an iterator that yields a sliding window over the elements of
+// an iterator. Each window is a newly allocated slice of n consecutive
+// elements, and the first element of each window is step elements further
+// along than the first element of the previous one. Only complete windows are
+// produced. If step is greater than n, some elements will not appear in any
+// window. If either n or step is less than 1, this function will panic with
+// [ErrInvalidChunkSize].
+//
+//	itr := iterator.Window(iterator.Range(0, 5), 3, 1)
+//	result := itr.Collect() // [][]int{{0,1,2},{1,2,3},{2,3,4}}
+func Window[T any](itr CoreIterator[T], n int, step int) Iterator[[]T] {
+	if n < 1 || step < 1 {
+		panic(fmt.Errorf("%w: window size %d, step %d", ErrInvalidChunkSize, n, step))
+	}
+	return NewDefaultIterator(&windowIter[T]{base: itr, n: n, step: step, need: n})
+}
+
+// Batch builds an iterator that yields the elements of an iterator in slices
+// of at most n elements. A slice is produced as soon as it holds n elements,
+// or as soon as timeout has elapsed since its first element was received,
+// whichever is first. A timeout of zero or less disables the time limit. The
+// source iterator is consumed in a separate goroutine, in the manner of
+// [Generate], which makes this function useful for batching elements that
+// arrive at irregular intervals. Calling Abort() on the returned iterator
+// causes the source iterator to be aborted, and waits until it has been. If n
+// is less than 1, this function will panic with [ErrInvalidChunkSize].
+func Batch[T any](itr CoreIterator[T], n int, timeout time.Duration) Iterator[[]T] {
+	if n < 1 {
+		panic(fmt.Errorf("%w: %d", ErrInvalidChunkSize, n))
+	}
+	initial := itr.Size()
+	values := make(chan T)
+	done := make(chan struct{})
+	fed := make(chan struct{})
+	go func() {
+		defer close(fed)
+		defer close(values)
+		send := func(v T) bool {
+			select {
+			case values <- v:
+				return true
+			case <-done:
+				return false
+			}
+		}
+		if itr.SeqOK() {
+			for v := range itr.Seq() {
+				if !send(v) {
+					itr.Abort()
+					break
+				}
+			}
+		} else {
+			for itr.Next() {
+				if !send(itr.Value()) {
+					itr.Abort()
+					break
+				}
+			}
+		}
+	}()
+	ch, abort := make(chan []T), make(chan struct{})
+	go runGenerator(Consumer[[]T]{sink: ch, done: abort}, func(c Consumer[[]T]) {
+		defer close(done)
+		batch := make([]T, 0, n)
+		var timer <-chan time.Time
+		for {
+			select {
+			case v, ok := <-values:
+				if !ok {
+					if len(batch) > 0 {
+						c.Yield(batch)
+					}
+					return
+				}
+				if len(batch) == 0 && timeout > 0 {
+					timer = time.After(timeout)
+				}
+				batch = append(batch, v)
+				if len(batch) < n {
+					continue
+				}
+			case <-timer:
+			case <-c.done:
+				return
+			}
+			c.Yield(batch)
+			batch = make([]T, 0, n)
+			timer = nil
+		}
+	})
+	return NewDefaultIterator(&batchIter[T]{genIter: newGenIter(ch, abort), fed: fed, initial: initial})
+}
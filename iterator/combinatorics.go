@@ -0,0 +1,569 @@
+package iterator
+
+import (
+	"fmt"
+	"iter"
+	"math"
+	"math/bits"
+)
+
+// mulInt multiplies two non-negative ints, returning false if the result
+// would overflow.
+func mulInt(a, b int) (int, bool) {
+	hi, lo := bits.Mul64(uint64(a), uint64(b))
+	if hi != 0 || lo > math.MaxInt {
+		return 0, false
+	}
+	return int(lo), true
+}
+
+// mulSize returns the size of an iterator that yields an element for every
+// pair of elements from two iterators of sizes a and b. A size that would
+// overflow an int is unknown.
+func mulSize(a, b IteratorSize) IteratorSize {
+	switch {
+	case a.IsKnownToBe(0) || b.IsKnownToBe(0):
+		return NewSize(0)
+	case a.IsInfinite() && b.IsInfinite():
+		return NewSizeInfinite()
+	case a.IsInfinite():
+		if b.IsKnown() {
+			return a
+		}
+		return NewSizeUnknown()
+	case b.IsInfinite():
+		if a.IsKnown() {
+			return b
+		}
+		return NewSizeUnknown()
+	case a.IsUnknown() || b.IsUnknown():
+		return NewSizeUnknown()
+	}
+	n, ok := mulInt(a.Size, b.Size)
+	switch {
+	case !ok:
+		return NewSizeUnknown()
+	case a.IsKnown() && b.IsKnown():
+		return NewSize(n)
+	default:
+		return NewSizeMax(n)
+	}
+}
+
+// permutationCount returns n!/(n-k)!, or false if the result would overflow.
+func permutationCount(n, k int) (int, bool) {
+	if k > n {
+		return 0, true
+	}
+	count := 1
+	for i := n - k + 1; i <= n; i++ {
+		var ok bool
+		if count, ok = mulInt(count, i); !ok {
+			return 0, false
+		}
+	}
+	return count, true
+}
+
+// combinationCount returns n!/(k!(n-k)!), or false if the result would
+// overflow.
+func combinationCount(n, k int) (int, bool) {
+	if k > n {
+		return 0, true
+	}
+	k = min(k, n-k)
+	count := 1
+	for i := 1; i <= k; i++ {
+		// count * (n-k+i) is always divisible by i
+		next, ok := mulInt(count, n-k+i)
+		if !ok {
+			return 0, false
+		}
+		count = next / i
+	}
+	return count, true
+}
+
+// product2Iter pairs each element of a with every element of b. The elements
+// of b are retained as they are read during the first pass, so that b is only
+// consumed once.
+type product2Iter[A any, B any] struct {
+	a     CoreIterator[A]
+	b     CoreIterator[B]
+	cache []B
+	bDone bool
+	haveA bool
+	pos   int
+	done  bool
+	key   A
+	value B
+}
+
+func (pi *product2Iter[A, B]) Next() bool {
+	if pi.done {
+		return false
+	}
+	for {
+		if !pi.haveA {
+			if !pi.a.Next() {
+				pi.Abort()
+				return false
+			}
+			pi.key, pi.haveA, pi.pos = pi.a.Value(), true, 0
+		}
+		if !pi.bDone {
+			if pi.b.Next() {
+				pi.value = pi.b.Value()
+				pi.cache = append(pi.cache, pi.value)
+				pi.pos++
+				return true
+			}
+			pi.bDone = true
+			if len(pi.cache) == 0 {
+				pi.Abort()
+				return false
+			}
+		}
+		if pi.pos < len(pi.cache) {
+			pi.value = pi.cache[pi.pos]
+			pi.pos++
+			return true
+		}
+		pi.haveA = false
+	}
+}
+
+func (pi *product2Iter[A, B]) Key() A {
+	return pi.key
+}
+
+func (pi *product2Iter[A, B]) Value() B {
+	return pi.value
+}
+
+func (pi *product2Iter[A, B]) Abort() {
+	if !pi.done {
+		pi.done = true
+		pi.a.Abort()
+		if !pi.bDone {
+			pi.b.Abort()
+		}
+	}
+}
+
+func (pi *product2Iter[A, B]) Reset() {
+	pi.a.Reset()
+	pi.b.Reset()
+	pi.cache = nil
+	pi.bDone = false
+	pi.haveA = false
+	pi.done = false
+}
+
+// Size is the number of pairs remaining for the current element of a, plus
+// one pair for every combination of the remaining elements of a with all the
+// elements of b.
+func (pi *product2Iter[A, B]) Size() IteratorSize {
+	if pi.done {
+		return NewSize(0)
+	}
+	var bSize, row IteratorSize
+	if pi.bDone {
+		bSize, row = NewSize(len(pi.cache)), NewSize(len(pi.cache)-pi.pos)
+	} else {
+		bSize, row = addSize(NewSize(len(pi.cache)), pi.b.Size()), pi.b.Size()
+	}
+	if !pi.haveA {
+		row = NewSize(0)
+	}
+	return addSize(row, mulSize(pi.a.Size(), bSize))
+}
+
+func (pi *product2Iter[A, B]) SeqOK() bool { return false }
+
+func (pi *product2Iter[A, B]) Seq() iter.Seq[B] {
+	return Seq(pi)
+}
+
+func (pi *product2Iter[A, B]) Seq2() iter.Seq2[A, B] {
+	return func(yield func(A, B) bool) {
+		for pi.Next() {
+			if !yield(pi.key, pi.value) {
+				pi.Abort()
+				break
+			}
+		}
+	}
+}
+
+// combinator generates a sequence of selections of elements, each of which is
+// described by a set of indices.
+type combinator[T any] interface {
+	// first sets up the first selection, returning false if there are none.
+	first() bool
+	// next advances to the next selection, returning false if there are no
+	// more.
+	next() bool
+	// current returns a newly allocated slice of the selected elements.
+	current() []T
+	// total returns the total number of selections.
+	total() IteratorSize
+	abort()
+	reset()
+}
+
+// combinIter is a core iterator over the selections generated by a
+// combinator.
+type combinIter[T any] struct {
+	combinator combinator[T]
+	value      []T
+	produced   int
+	started    bool
+	done       bool
+}
+
+func (ci *combinIter[T]) Next() bool {
+	if ci.done {
+		return false
+	}
+	var ok bool
+	if ci.started {
+		ok = ci.combinator.next()
+	} else {
+		ci.started = true
+		ok = ci.combinator.first()
+	}
+	if !ok {
+		ci.done = true
+		return false
+	}
+	ci.value = ci.combinator.current()
+	ci.produced++
+	return true
+}
+
+func (ci *combinIter[T]) Value() []T {
+	return ci.value
+}
+
+func (ci *combinIter[T]) Abort() {
+	if !ci.done {
+		ci.done = true
+		ci.combinator.abort()
+	}
+}
+
+func (ci *combinIter[T]) Reset() {
+	ci.combinator.reset()
+	ci.produced = 0
+	ci.started = false
+	ci.done = false
+}
+
+func (ci *combinIter[T]) Size() IteratorSize {
+	if ci.done {
+		return NewSize(0)
+	}
+	total := ci.combinator.total()
+	switch total.Type {
+	case SizeKnown:
+		return NewSize(total.Size - ci.produced)
+	case SizeAtMost:
+		return NewSizeMax(total.Size - ci.produced)
+	default:
+		return total
+	}
+}
+
+func (ci *combinIter[T]) SeqOK() bool { return false }
+
+func (ci *combinIter[T]) Seq() iter.Seq[[]T] {
+	return Seq(ci)
+}
+
+// selection holds a slice of elements and the indices of the currently
+// selected ones.
+type selection[T any] struct {
+	elements []T
+	indices  []int
+}
+
+func (s *selection[T]) current() []T {
+	result := make([]T, len(s.indices))
+	for i, index := range s.indices {
+		result[i] = s.elements[index]
+	}
+	return result
+}
+
+func (s *selection[T]) abort() {}
+func (s *selection[T]) reset() {}
+
+// permutations generates k-length permutations in lexicographic order of
+// index.
+type permutations[T any] struct {
+	selection[T]
+	k      int
+	cycles []int
+	all    []int
+}
+
+func (p *permutations[T]) first() bool {
+	n := len(p.elements)
+	if p.k > n {
+		return false
+	}
+	p.all = make([]int, n)
+	for i := range p.all {
+		p.all[i] = i
+	}
+	p.cycles = make([]int, p.k)
+	for i := range p.cycles {
+		p.cycles[i] = n - i
+	}
+	p.indices = p.all[:p.k]
+	return true
+}
+
+func (p *permutations[T]) next() bool {
+	n := len(p.elements)
+	for i := p.k - 1; i >= 0; i-- {
+		p.cycles[i]--
+		if p.cycles[i] == 0 {
+			// Rotate the element at i to the end
+			moved := p.all[i]
+			copy(p.all[i:], p.all[i+1:])
+			p.all[n-1] = moved
+			p.cycles[i] = n - i
+		} else {
+			j := n - p.cycles[i]
+			p.all[i], p.all[j] = p.all[j], p.all[i]
+			return true
+		}
+	}
+	return false
+}
+
+func (p *permutations[T]) total() IteratorSize {
+	if count, ok := permutationCount(len(p.elements), p.k); ok {
+		return NewSize(count)
+	}
+	return NewSizeUnknown()
+}
+
+// combinations generates k-length combinations in lexicographic order of
+// index.
+type combinations[T any] struct {
+	selection[T]
+	k int
+}
+
+func (c *combinations[T]) first() bool {
+	if c.k > len(c.elements) {
+		return false
+	}
+	c.indices = make([]int, c.k)
+	for i := range c.indices {
+		c.indices[i] = i
+	}
+	return true
+}
+
+func (c *combinations[T]) next() bool {
+	n := len(c.elements)
+	i := c.k - 1
+	for i >= 0 && c.indices[i] == i+n-c.k {
+		i--
+	}
+	if i < 0 {
+		return false
+	}
+	c.indices[i]++
+	for j := i + 1; j < c.k; j++ {
+		c.indices[j] = c.indices[j-1] + 1
+	}
+	return true
+}
+
+func (c *combinations[T]) total() IteratorSize {
+	if count, ok := combinationCount(len(c.elements), c.k); ok {
+		return NewSize(count)
+	}
+	return NewSizeUnknown()
+}
+
+// powerSet generates the combinations of each length in turn, from zero up to
+// the number of elements.
+type powerSet[T any] struct {
+	combinations[T]
+}
+
+func (ps *powerSet[T]) first() bool {
+	ps.k = 0
+	return ps.combinations.first()
+}
+
+func (ps *powerSet[T]) next() bool {
+	if ps.combinations.next() {
+		return true
+	}
+	ps.k++
+	return ps.combinations.first()
+}
+
+func (ps *powerSet[T]) total() IteratorSize {
+	if n := len(ps.elements); n < bits.UintSize-1 {
+		return NewSize(1 << n)
+	}
+	return NewSizeUnknown()
+}
+
+// cartesian generates the cartesian product of a number of iterators, which
+// are collected into pools when the first element is requested.
+type cartesian[T any] struct {
+	inputs  []CoreIterator[T]
+	pools   [][]T
+	indices []int
+}
+
+func (c *cartesian[T]) first() bool {
+	c.pools = make([][]T, len(c.inputs))
+	for i, input := range c.inputs {
+		c.pools[i] = Collect(input)
+	}
+	c.indices = make([]int, len(c.pools))
+	for _, pool := range c.pools {
+		if len(pool) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *cartesian[T]) next() bool {
+	for i := len(c.indices) - 1; i >= 0; i-- {
+		c.indices[i]++
+		if c.indices[i] < len(c.pools[i]) {
+			return true
+		}
+		c.indices[i] = 0
+	}
+	return false
+}
+
+func (c *cartesian[T]) current() []T {
+	result := make([]T, len(c.indices))
+	for i, index := range c.indices {
+		result[i] = c.pools[i][index]
+	}
+	return result
+}
+
+// total is computed from the sizes of the inputs until they have been
+// collected, after which it is exact.
+func (c *cartesian[T]) total() IteratorSize {
+	size := NewSize(1)
+	if c.pools != nil {
+		for _, pool := range c.pools {
+			size = mulSize(size, NewSize(len(pool)))
+		}
+	} else {
+		for _, input := range c.inputs {
+			size = mulSize(size, input.Size())
+		}
+	}
+	return size
+}
+
+func (c *cartesian[T]) abort() {
+	if c.pools == nil {
+		for _, input := range c.inputs {
+			input.Abort()
+		}
+	}
+}
+
+func (c *cartesian[T]) reset() {
+	for _, input := range c.inputs {
+		input.Reset()
+	}
+	c.pools = nil
+	c.indices = nil
+}
+
+func validateSelection(k int) {
+	if k < 0 {
+		panic(fmt.Errorf("%w: selection length %d is negative", ErrInvalidIteratorRange, k))
+	}
+}
+
+// Product2 produces an iterator over the cartesian product of two iterators,
+// yielding a key and value pair for every combination of an element of a
+// with an element of b. The pairs are ordered by the elements of a, then by
+// the elements of b. The elements of b are retained in memory as they are
+// read, so that each iterator is only consumed once. The size of the result is
+// the product of the sizes of the two iterators, or unknown if that would
+// overflow. E.g.
+//
+//	itr := iterator.Product2(iterator.Of("x", "y"), iterator.Of(1, 2))
+//	result := itr.Collect2() // []KeyValue[string,int]{{"x",1},{"x",2},{"y",1},{"y",2}}
+func Product2[A any, B any](a CoreIterator[A], b CoreIterator[B]) Iterator2[A, B] {
+	return NewDefaultIterator2(&product2Iter[A, B]{a: a, b: b})
+}
+
+// ProductN produces an iterator over the cartesian product of any number of
+// iterators, yielding a slice for every combination of one element from each
+// of them, in the order of the iterators. The slices are ordered as an
+// odometer would be, with the last position changing fastest. All the
+// iterators are collected into memory when the first element is requested, so
+// this function will panic with [ErrSizeInfinite] at that point if any of them
+// is known to be infinite. Each slice produced is newly allocated. With no
+// iterators, a single empty slice is produced. E.g.
+//
+//	itr := iterator.ProductN(iterator.Of(1, 2), iterator.Of(3), iterator.Of(4, 5))
+//	result := itr.Collect() // [][]int{{1,3,4},{1,3,5},{2,3,4},{2,3,5}}
+func ProductN[T any](itrs ...CoreIterator[T]) Iterator[[]T] {
+	return NewDefaultIterator[[]T](&combinIter[T]{combinator: &cartesian[T]{inputs: itrs}})
+}
+
+// Permutations produces an iterator over all the orderings of k elements
+// chosen from a slice, yielding n!/(n-k)! permutations for a slice of length
+// n, or none if k is greater than n. Elements are treated as distinct by
+// position rather than by value, and the permutations are produced in
+// lexicographic order of position. Each slice produced is newly allocated.
+// This function will panic with [ErrInvalidIteratorRange] if k is negative.
+// E.g.
+//
+//	itr := iterator.Permutations([]int{1, 2, 3}, 2)
+//	result := itr.Collect() // [][]int{{1,2},{1,3},{2,1},{2,3},{3,1},{3,2}}
+func Permutations[T any](slice []T, k int) Iterator[[]T] {
+	validateSelection(k)
+	return NewDefaultIterator[[]T](&combinIter[T]{combinator: &permutations[T]{selection: selection[T]{elements: slice}, k: k}})
+}
+
+// Combinations produces an iterator over all the selections of k elements
+// from a slice, without regard to order, yielding n!/(k!(n-k)!) combinations
+// for a slice of length n, or none if k is greater than n. Elements are
+// treated as distinct by position rather than by value. The elements of each
+// combination retain their order in the slice, and the combinations are
+// produced in lexicographic order of position. Each slice produced is newly
+// allocated. This function will panic with [ErrInvalidIteratorRange] if k is
+// negative. E.g.
+//
+//	itr := iterator.Combinations([]int{1, 2, 3}, 2)
+//	result := itr.Collect() // [][]int{{1,2},{1,3},{2,3}}
+func Combinations[T any](slice []T, k int) Iterator[[]T] {
+	validateSelection(k)
+	return NewDefaultIterator[[]T](&combinIter[T]{combinator: &combinations[T]{selection: selection[T]{elements: slice}, k: k}})
+}
+
+// PowerSet produces an iterator over all the subsets of the elements of a
+// slice, yielding 2^n subsets for a slice of length n. The subsets are
+// produced in order of increasing length, and subsets of the same length are
+// ordered as by [Combinations]. Each slice produced is newly allocated. E.g.
+//
+//	itr := iterator.PowerSet([]int{1, 2, 3})
+//	result := itr.Collect() // [][]int{{},{1},{2},{3},{1,2},{1,3},{2,3},{1,2,3}}
+func PowerSet[T any](slice []T) Iterator[[]T] {
+	return NewDefaultIterator[[]T](&combinIter[T]{combinator: &powerSet[T]{combinations[T]{selection: selection[T]{elements: slice}}}})
+}
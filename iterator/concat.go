@@ -0,0 +1,175 @@
+package iterator
+
+import "iter"
+
+type concatIter[T any] struct {
+	parts []CoreIterator[T]
+	index int
+	value T
+}
+
+func (ci *concatIter[T]) Next() bool {
+	for ; ci.index < len(ci.parts); ci.index++ {
+		if part := ci.parts[ci.index]; part.Next() {
+			ci.value = part.Value()
+			return true
+		}
+	}
+	return false
+}
+
+func (ci *concatIter[T]) Value() T {
+	return ci.value
+}
+
+func (ci *concatIter[T]) Abort() {
+	for ; ci.index < len(ci.parts); ci.index++ {
+		ci.parts[ci.index].Abort()
+	}
+}
+
+func (ci *concatIter[T]) Reset() {
+	for _, part := range ci.parts {
+		part.Reset()
+	}
+	ci.index = 0
+}
+
+func (ci *concatIter[T]) Size() IteratorSize {
+	size := NewSize(0)
+	for _, part := range ci.parts[ci.index:] {
+		size = addSize(size, part.Size())
+	}
+	return size
+}
+
+// SeqOK is true only if all the remaining parts of the iterator are SeqOK.
+func (ci *concatIter[T]) SeqOK() bool {
+	for _, part := range ci.parts[ci.index:] {
+		if !part.SeqOK() {
+			return false
+		}
+	}
+	return true
+}
+
+func (ci *concatIter[T]) Seq() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for ; ci.index < len(ci.parts); ci.index++ {
+			part := ci.parts[ci.index]
+			if part.SeqOK() {
+				for v := range part.Seq() {
+					if !yield(v) {
+						return
+					}
+				}
+			} else {
+				for part.Next() {
+					if !yield(part.Value()) {
+						part.Abort()
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+type flatMapIter[T any, U any] struct {
+	outer   CoreIterator[T]
+	mapping func(T) CoreIterator[U]
+	inner   CoreIterator[U]
+	value   U
+}
+
+func (fi *flatMapIter[T, U]) Next() bool {
+	for {
+		if fi.inner != nil && fi.inner.Next() {
+			fi.value = fi.inner.Value()
+			return true
+		}
+		if !fi.outer.Next() {
+			fi.inner = nil
+			return false
+		}
+		fi.inner = fi.mapping(fi.outer.Value())
+	}
+}
+
+func (fi *flatMapIter[T, U]) Value() U {
+	return fi.value
+}
+
+func (fi *flatMapIter[T, U]) Abort() {
+	if fi.inner != nil {
+		fi.inner.Abort()
+		fi.inner = nil
+	}
+	fi.outer.Abort()
+}
+
+func (fi *flatMapIter[T, U]) Reset() {
+	fi.inner = nil
+	fi.outer.Reset()
+}
+
+// Size is only known when the outer iterator is known to be exhausted, in
+// which case it is the size of the current inner iterator.
+func (fi *flatMapIter[T, U]) Size() IteratorSize {
+	innerSize := NewSize(0)
+	if fi.inner != nil {
+		innerSize = fi.inner.Size()
+	}
+	if innerSize.IsInfinite() || fi.outer.Size().IsKnownToBe(0) {
+		return innerSize
+	}
+	return NewSizeUnknown()
+}
+
+func (fi *flatMapIter[T, U]) SeqOK() bool {
+	return fi.inner == nil && fi.outer.SeqOK()
+}
+
+func (fi *flatMapIter[T, U]) Seq() iter.Seq[U] {
+	return func(yield func(U) bool) {
+		for v := range fi.outer.Seq() {
+			inner := fi.mapping(v)
+			if inner.SeqOK() {
+				for u := range inner.Seq() {
+					if !yield(u) {
+						return
+					}
+				}
+			} else {
+				for inner.Next() {
+					if !yield(inner.Value()) {
+						inner.Abort()
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+// Concat builds an iterator that yields all the elements of each of the
+// supplied iterators in turn. The size of the resulting iterator is the sum of
+// the sizes of the supplied iterators; it is known if all the sizes are known,
+// and infinite if any of them are infinite. If all the supplied iterators are
+// able to use their Seq() method, so will the resulting iterator.
+func Concat[T any](itrs ...CoreIterator[T]) Iterator[T] {
+	return NewDefaultIterator(&concatIter[T]{parts: itrs})
+}
+
+// FlatMap applies function mapping to each element of an iterator, producing
+// an iterator for each one. The resulting iterator yields all the elements of
+// each of these iterators in turn.
+func FlatMap[T any, U any](itr CoreIterator[T], mapping func(T) CoreIterator[U]) Iterator[U] {
+	return NewDefaultIterator(&flatMapIter[T, U]{outer: itr, mapping: mapping})
+}
+
+// Flatten takes an iterator of iterators and builds an iterator that yields
+// all the elements of each of the inner iterators in turn.
+func Flatten[T any](itr CoreIterator[Iterator[T]]) Iterator[T] {
+	return FlatMap(itr, func(inner Iterator[T]) CoreIterator[T] { return inner })
+}
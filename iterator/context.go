@@ -0,0 +1,150 @@
+package iterator
+
+import (
+	"context"
+	"iter"
+
+	"github.com/robdavid/genutil-go/errors/result"
+)
+
+// genCtxIter is a core iterator that obtains values from a generator running
+// under a context. The generator is stopped by cancelling its own context,
+// derived from the parent, rather than by closing the channel, so the channel
+// is only ever closed by the generator goroutine.
+type genCtxIter[T any] struct {
+	source chan T
+	parent context.Context
+	cancel context.CancelFunc
+	value  T
+	err    error
+	done   bool
+}
+
+func newGenCtxIter[T any](ctx context.Context) (*genCtxIter[T], Consumer[T]) {
+	genCtx, cancel := context.WithCancel(ctx)
+	ch := make(chan T)
+	return &genCtxIter[T]{source: ch, parent: ctx, cancel: cancel}, Consumer[T]{sink: ch, done: genCtx.Done(), ctx: genCtx}
+}
+
+// stop ends the iteration with the given error, which may be nil, and stops
+// the generator.
+func (gi *genCtxIter[T]) stop(err error) {
+	gi.err = err
+	gi.done = true
+	gi.cancel()
+}
+
+func (gi *genCtxIter[T]) Next() bool {
+	if gi.done {
+		return false
+	}
+	if err := gi.parent.Err(); err != nil {
+		gi.stop(err)
+		return false
+	}
+	select {
+	case value, ok := <-gi.source:
+		if !ok {
+			// The generator may have ended because the context is done
+			gi.stop(gi.parent.Err())
+			return false
+		}
+		gi.value = value
+		return true
+	case <-gi.parent.Done():
+		gi.stop(gi.parent.Err())
+		return false
+	}
+}
+
+func (gi *genCtxIter[T]) Value() T {
+	return gi.value
+}
+
+func (gi *genCtxIter[T]) Err() error {
+	return gi.err
+}
+
+func (gi *genCtxIter[T]) Abort() {
+	if !gi.done {
+		gi.stop(nil)
+	}
+}
+
+// Reset is the same as abort for this iterator
+func (gi *genCtxIter[T]) Reset() {
+	gi.Abort()
+}
+
+func (gi *genCtxIter[T]) Size() IteratorSize {
+	if gi.done {
+		return NewSize(0)
+	}
+	return NewSizeUnknown()
+}
+
+func (gi *genCtxIter[T]) Seq() iter.Seq[T] {
+	return Seq(gi)
+}
+
+func (gi *genCtxIter[T]) SeqOK() bool {
+	return false
+}
+
+// genResultsCtxIter yields the values of a generator of results running under
+// a context. Iteration stops at the first error result, or once the context is
+// done, and the error is reported by Err().
+type genResultsCtxIter[T any] struct {
+	*genCtxIter[result.Result[T]]
+	value T
+}
+
+func (gr *genResultsCtxIter[T]) Next() bool {
+	if !gr.genCtxIter.Next() {
+		return false
+	}
+	res := gr.genCtxIter.value
+	if res.IsError() {
+		gr.stop(res.GetErr())
+		return false
+	}
+	gr.value = res.Get()
+	return true
+}
+
+func (gr *genResultsCtxIter[T]) Value() T {
+	return gr.value
+}
+
+func (gr *genResultsCtxIter[T]) Seq() iter.Seq[T] {
+	return Seq(gr)
+}
+
+/*
+GenerateCtx is a variation on [Generate] in which the generator function runs
+under the context ctx. Once the context is done, the iterator stops producing
+values, and its Err() method returns the error reported by ctx.Err(). The
+generator is stopped by an [AbortGenerator] panic raised from its next call to
+Yield, or from any Yield call blocked waiting for the consumer. Calling Abort()
+on the iterator stops the generator in the same way, but leaves Err()
+returning nil. A generator that may block for a long period between values
+should watch the context returned by [Consumer.Context] so that it can return
+promptly, ensuring that its goroutine does not outlive the context.
+*/
+func GenerateCtx[T any](ctx context.Context, generator Generator[T]) ErrIterator[T] {
+	itr, yield := newGenCtxIter[T](ctx)
+	go runGenerator(yield, generator)
+	return NewDefaultErrIterator(itr)
+}
+
+// GenerateResultsCtx is a variation on [GenerateCtx] for a generator function
+// that yields results. The iterator produces the values of successful
+// results, and stops at the first error result, or once the context is done,
+// whichever comes first; its Err() method then returns the error. In this way,
+// errors from the generator and from the context are reported in the same
+// manner as by [GenerateCtx]. The generator is stopped once iteration stops.
+func GenerateResultsCtx[T any](ctx context.Context, generator ResultGenerator[T]) ErrIterator[T] {
+	itr, yield := newGenCtxIter[result.Result[T]](ctx)
+	go runResultGenerator(ResultConsumer[T](yield), generator)
+	return NewDefaultErrIterator(&genResultsCtxIter[T]{genCtxIter: itr})
+}
@@ -0,0 +1,123 @@
+package iterator
+
+import "github.com/robdavid/genutil-go/functions"
+
+// resetIter wraps a core iterator whose elements are produced with the help of
+// some additional state, which is cleared by onReset when the iterator is
+// reset.
+type resetIter[T any] struct {
+	CoreIterator[T]
+	onReset func()
+}
+
+func (ri *resetIter[T]) Reset() {
+	ri.onReset()
+	ri.CoreIterator.Reset()
+}
+
+// resetIter2 is the [CoreIterator2] equivalent of resetIter.
+type resetIter2[K any, V any] struct {
+	CoreIterator2[K, V]
+	onReset func()
+}
+
+func (ri *resetIter2[K, V]) Reset() {
+	ri.onReset()
+	ri.CoreIterator2.Reset()
+}
+
+func subsetSize(sz IteratorSize) IteratorSize { return sz.Subset() }
+
+// distinctFilter returns a filter function that accepts only the first element
+// seen for each key, along with a function to forget the keys seen so far.
+func distinctFilter[T any, K comparable](keyFn func(T) K) (func(T) bool, func()) {
+	seen := make(map[K]struct{})
+	filter := func(v T) bool {
+		k := keyFn(v)
+		if _, ok := seen[k]; ok {
+			return false
+		}
+		seen[k] = struct{}{}
+		return true
+	}
+	return filter, func() { clear(seen) }
+}
+
+// dedupFilter returns a filter function that accepts only elements that are
+// not equal to the one before, along with a function to forget the last
+// element seen.
+func dedupFilter[T comparable]() (func(T) bool, func()) {
+	var last T
+	started := false
+	filter := func(v T) bool {
+		if started && v == last {
+			return false
+		}
+		last, started = v, true
+		return true
+	}
+	return filter, func() { started = false }
+}
+
+func filterWithReset[T any](itr CoreIterator[T], filter func(T) bool, onReset func()) Iterator[T] {
+	filterNext := func(v T) (T, bool) { return v, filter(v) }
+	core := &mapIter[T, T]{mapIterBase: newMapIterBase[T, T](itr, subsetSize), mapping: filterNext}
+	return NewDefaultIterator[T](&resetIter[T]{core, onReset})
+}
+
+func filterWithReset2[K any, V any](itr CoreIterator2[K, V], filter func(K, V) bool, onReset func()) Iterator2[K, V] {
+	filterNext := func(k K, v V) (K, V, bool) { return k, v, filter(k, v) }
+	core := &mapIter2[K, V, K, V]{mapIterBase: newMapIterBase[V, V](itr, subsetSize), mapping2: filterNext, base2: itr}
+	return NewDefaultIterator2[K, V](&resetIter2[K, V]{core, onReset})
+}
+
+// Distinct produces an iterator containing the elements of an iterator with
+// any duplicates removed; only the first occurrence of each element is
+// retained. The elements seen so far are held in a set, so memory use grows
+// with the number of distinct elements. For a cheaper alternative that removes
+// only consecutive duplicates, see [Dedup].
+func Distinct[T comparable](itr CoreIterator[T]) Iterator[T] {
+	return DistinctBy(itr, functions.Id)
+}
+
+// DistinctBy produces an iterator containing the elements of an iterator for
+// which function keyFn returns a key that has not been seen before; only the
+// first element with each key is retained.
+func DistinctBy[T any, K comparable](itr CoreIterator[T], keyFn func(T) K) Iterator[T] {
+	filter, onReset := distinctFilter(keyFn)
+	return filterWithReset(itr, filter, onReset)
+}
+
+// Dedup produces an iterator containing the elements of an iterator with
+// consecutive duplicates removed, so that a run of equal elements is reduced
+// to a single element. Only the previous element is retained, so memory use is
+// constant.
+//
+//	itr := iterator.Dedup(iterator.Of(1, 1, 2, 2, 2, 1))
+//	result := itr.Collect() // []int{1,2,1}
+func Dedup[T comparable](itr CoreIterator[T]) Iterator[T] {
+	filter, onReset := dedupFilter[T]()
+	return filterWithReset(itr, filter, onReset)
+}
+
+// Distinct2 produces an iterator containing the key and value pairs of an
+// iterator whose value has not been seen before; only the first pair with
+// each value is retained.
+func Distinct2[K any, V comparable](itr CoreIterator2[K, V]) Iterator2[K, V] {
+	return DistinctBy2(itr, func(_ K, v V) V { return v })
+}
+
+// DistinctBy2 produces an iterator containing the key and value pairs of an
+// iterator for which function keyFn returns a result that has not been seen
+// before; only the first pair with each result is retained.
+func DistinctBy2[K any, V any, D comparable](itr CoreIterator2[K, V], keyFn func(K, V) D) Iterator2[K, V] {
+	filter, onReset := distinctFilter(func(kv KeyValue[K, V]) D { return keyFn(kv.Key, kv.Value) })
+	return filterWithReset2(itr, func(k K, v V) bool { return filter(KVOf(k, v)) }, onReset)
+}
+
+// Dedup2 produces an iterator containing the key and value pairs of an
+// iterator with pairs removed whose value is equal to that of the pair before.
+func Dedup2[K any, V comparable](itr CoreIterator2[K, V]) Iterator2[K, V] {
+	filter, onReset := dedupFilter[V]()
+	return filterWithReset2(itr, func(_ K, v V) bool { return filter(v) }, onReset)
+}
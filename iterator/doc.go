@@ -68,6 +68,11 @@ Out of the box methods exist to produce iterators:
   - Over number ranges via [iterator.Range], [iterator.IncRange],
     [iterator.RangyBy] or [iterator.IncRangeBy] functions.
   - Over an explicit list of elements via the [iterator.Of] function.
+  - Over repeated or generated values via the [iterator.Repeat],
+    [iterator.RepeatN], [iterator.Cycle], [iterator.Iterate] or
+    [iterator.Unfold] functions. Apart from [iterator.RepeatN] and
+    [iterator.Unfold], these produce iterators of infinite size, which must be
+    limited, e.g. with [iterator.Take], before being collected.
 
 # User iterators
 
@@ -95,6 +100,18 @@ New iterators can be created a number of ways.
   - A user may create an implementation of [iterator.CoreMutableIterator2] and
     convert it to an [iterator.MutableIterator2] with
     [iterator.NewDefaultMutableIterator2].
+  - An [iter.Seq2] of values and errors can be transformed to an
+    [iterator.ErrIterator] via the [iterator.NewErr] function. A user may also
+    create an implementation of [iterator.CoreErrIterator] and convert it to an
+    [iterator.ErrIterator] with [iterator.NewDefaultErrIterator].
+  - A user may create an implementation of [iterator.CoreDoubleEndedIterator]
+    and convert it to an [iterator.DoubleEndedIterator] with
+    [iterator.NewDefaultDoubleEndedIterator]. Such iterators can be reversed
+    by [iterator.Rev] without buffering their elements.
+  - Similarly, an implementation of [iterator.CoreRandomAccessIterator] may be
+    converted to an [iterator.RandomAccessIterator] with
+    [iterator.NewDefaultRandomAccessIterator], so that adapters such as
+    [iterator.Skip] and [iterator.Take] can access its elements by position.
 
 # Consumption
 
@@ -182,8 +199,16 @@ below.
   - [iterator.DefaultIterator.Intercalate1]
   - [iterator.DefaultIterator.Morph]
   - [iterator.DefaultIterator2.Morph2]
+  - [iterator.DefaultIterator.Skip]
+  - [iterator.DefaultIterator2.Skip2]
+  - [iterator.DefaultIterator.SkipWhile]
+  - [iterator.DefaultIterator2.SkipWhile2]
+  - [iterator.DefaultIterator.StepBy]
+  - [iterator.DefaultIterator2.StepBy2]
   - [iterator.DefaultIterator.Take]
   - [iterator.DefaultIterator2.Take2]
+  - [iterator.DefaultIterator.TakeWhile]
+  - [iterator.DefaultIterator2.TakeWhile2]
 
 There are also corresponding functions in the iterator package, which include
 some additional or modified functions that can't be expressed as methods due to
@@ -192,8 +217,12 @@ limitations of generics. All methods defined in [iterator.DefaultIterator] or
 
   - [iterator.All]
   - [iterator.Any]
+  - [iterator.Average]
+  - [iterator.Batch]
   - [iterator.Chan]
   - [iterator.Chan2]
+  - [iterator.Chunk]
+  - [iterator.ChunkBy]
   - [iterator.Take]
   - [iterator.Collect]
   - [iterator.Collect2]
@@ -203,21 +232,86 @@ limitations of generics. All methods defined in [iterator.DefaultIterator] or
   - [iterator.CollectIntoCap]
   - [iterator.CollectIntoMap]
   - [iterator.CollectMap]
+  - [iterator.Combinations]
+  - [iterator.Concat]
+  - [iterator.Count]
+  - [iterator.CountBy]
+  - [iterator.Cycle]
+  - [iterator.Dedup]
+  - [iterator.Dedup2]
+  - [iterator.Difference]
+  - [iterator.Distinct]
+  - [iterator.Distinct2]
+  - [iterator.DistinctBy]
+  - [iterator.DistinctBy2]
   - [iterator.Enumerate]
   - [iterator.Filter]
   - [iterator.Filter2]
   - [iterator.FilterMap]
   - [iterator.FilterMap2]
+  - [iterator.FlatMap]
+  - [iterator.Flatten]
   - [iterator.Fold]
   - [iterator.Fold1]
+  - [iterator.FromResults]
+  - [iterator.GroupBy]
   - [iterator.Intercalate]
   - [iterator.Intercalate1]
+  - [iterator.Intersect]
+  - [iterator.Iterate]
   - [iterator.Map]
   - [iterator.Map2]
+  - [iterator.MapErr]
+  - [iterator.Max]
+  - [iterator.MaxBy]
+  - [iterator.Memoize]
+  - [iterator.MergeJoin]
+  - [iterator.MergeSorted]
+  - [iterator.Min]
+  - [iterator.MinBy]
+  - [iterator.ParCollect]
+  - [iterator.ParFilter]
+  - [iterator.ParFilterMap]
+  - [iterator.ParFold]
+  - [iterator.ParForEach]
+  - [iterator.ParMap]
+  - [iterator.Permutations]
+  - [iterator.PowerSet]
+  - [iterator.PrefixProduct]
+  - [iterator.PrefixSum]
+  - [iterator.Product]
+  - [iterator.Product2]
+  - [iterator.ProductN]
+  - [iterator.Repeat]
+  - [iterator.RepeatN]
+  - [iterator.Rev]
+  - [iterator.Rev2]
+  - [iterator.Scan]
+  - [iterator.Scan1]
   - [iterator.Seq]
   - [iterator.Seq2]
+  - [iterator.SeqErr]
+  - [iterator.Skip]
+  - [iterator.Skip2]
+  - [iterator.SkipWhile]
+  - [iterator.SkipWhile2]
+  - [iterator.StepBy]
+  - [iterator.StepBy2]
+  - [iterator.Stats]
+  - [iterator.Sum]
   - [iterator.Take]
   - [iterator.Take2]
+  - [iterator.TakeWhile]
+  - [iterator.TakeWhile2]
+  - [iterator.Tee]
+  - [iterator.ToResults]
+  - [iterator.TryMap]
+  - [iterator.Unfold]
+  - [iterator.Unzip]
+  - [iterator.Window]
+  - [iterator.Zip]
+  - [iterator.ZipLongest]
+  - [iterator.ZipWith]
 
 **/
 package iterator
@@ -9,6 +9,7 @@ type emptyIter2[K any, V any] struct {
 }
 
 func (emptyIter[T]) Next() bool                { return false }
+func (emptyIter[T]) NextBack() bool            { return false }
 func (emptyIter[T]) Value() T                  { var zero T; return zero }
 func (emptyIter[T]) Size() IteratorSize        { return NewSize(0) }
 func (emptyIter[T]) Abort()                    {}
@@ -18,9 +19,10 @@ func (emptyIter[T]) Seq() iter.Seq[T]          { return func(yield func(T) bool)
 func (emptyIter2[K, V]) Key() K                { var zero K; return zero }
 func (emptyIter2[K, V]) Seq2() iter.Seq2[K, V] { return func(yield func(K, V) bool) {} }
 
-// Empty creates an iterator that returns no items.
+// Empty creates an iterator that returns no items. It is double ended, so it
+// may be converted to a [DoubleEndedIterator].
 func Empty[T any]() Iterator[T] {
-	return NewDefaultIterator(emptyIter[T]{})
+	return NewDefaultDoubleEndedIterator[T](emptyIter[T]{})
 }
 
 // Empty2 creates an Iterator2 that returns no items.
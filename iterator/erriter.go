@@ -0,0 +1,229 @@
+package iterator
+
+import (
+	"iter"
+
+	"github.com/robdavid/genutil-go/errors/result"
+)
+
+// DefaultErrIterator wraps a [CoreErrIterator] together with a [DefaultIterator]
+// to provide an implementation of [ErrIterator].
+type DefaultErrIterator[T any] struct {
+	CoreErrIterator[T]
+	DefaultIterator[T]
+}
+
+// NewDefaultErrIterator builds an [ErrIterator] from a [CoreErrIterator] by
+// adding the methods of [IteratorExtensions].
+func NewDefaultErrIterator[T any](citr CoreErrIterator[T]) DefaultErrIterator[T] {
+	return DefaultErrIterator[T]{CoreErrIterator: citr, DefaultIterator: DefaultIterator[T]{CoreIterator: citr}}
+}
+
+// errSource is implemented by iterators that can report an error.
+type errSource interface {
+	Err() error
+}
+
+// tryMapIter applies a fallible mapping function to each element of an
+// iterator, stopping at the first error.
+type tryMapIter[T any, U any] struct {
+	base    CoreIterator[T]
+	mapping func(T) (U, error)
+	value   U
+	err     error
+	done    bool
+}
+
+func (tm *tryMapIter[T, U]) Next() bool {
+	if tm.done {
+		return false
+	}
+	if !tm.base.Next() {
+		tm.done = true
+		if source, ok := tm.base.(errSource); ok {
+			tm.err = source.Err()
+		}
+		return false
+	}
+	value, err := tm.mapping(tm.base.Value())
+	if err != nil {
+		tm.err = err
+		tm.Abort()
+		return false
+	}
+	tm.value = value
+	return true
+}
+
+func (tm *tryMapIter[T, U]) Value() U {
+	return tm.value
+}
+
+func (tm *tryMapIter[T, U]) Err() error {
+	return tm.err
+}
+
+func (tm *tryMapIter[T, U]) Abort() {
+	tm.done = true
+	tm.base.Abort()
+}
+
+func (tm *tryMapIter[T, U]) Reset() {
+	tm.base.Reset()
+	tm.err = nil
+	tm.done = false
+}
+
+// Size is at most the size of the underlying iterator, since iteration may
+// stop early on error.
+func (tm *tryMapIter[T, U]) Size() IteratorSize {
+	if tm.done {
+		return NewSize(0)
+	}
+	size := tm.base.Size()
+	if size.IsInfinite() {
+		return NewSizeUnknown()
+	}
+	return size.Subset()
+}
+
+func (tm *tryMapIter[T, U]) SeqOK() bool { return false }
+
+func (tm *tryMapIter[T, U]) Seq() iter.Seq[U] {
+	return Seq(tm)
+}
+
+// mapErrIter transforms the error reported by an error iterator.
+type mapErrIter[T any] struct {
+	CoreErrIterator[T]
+	mapping func(error) error
+}
+
+func (me *mapErrIter[T]) Err() error {
+	if err := me.CoreErrIterator.Err(); err != nil {
+		return me.mapping(err)
+	}
+	return nil
+}
+
+// toResultsIter yields the elements of an error iterator as successful
+// results, followed by a final error result if the iterator stopped on error.
+type toResultsIter[T any] struct {
+	base  CoreErrIterator[T]
+	value result.Result[T]
+	done  bool
+}
+
+func (tr *toResultsIter[T]) Next() bool {
+	if tr.done {
+		return false
+	}
+	if tr.base.Next() {
+		tr.value = result.Value(tr.base.Value())
+		return true
+	}
+	tr.done = true
+	if err := tr.base.Err(); err != nil {
+		tr.value = result.Error[T](err)
+		return true
+	}
+	return false
+}
+
+func (tr *toResultsIter[T]) Value() result.Result[T] {
+	return tr.value
+}
+
+func (tr *toResultsIter[T]) Abort() {
+	tr.done = true
+	tr.base.Abort()
+}
+
+func (tr *toResultsIter[T]) Reset() {
+	tr.done = false
+	tr.base.Reset()
+}
+
+// Size allows for a final error result in addition to the elements of the
+// underlying iterator.
+func (tr *toResultsIter[T]) Size() IteratorSize {
+	if tr.done {
+		return NewSize(0)
+	}
+	size := tr.base.Size()
+	switch size.Type {
+	case SizeKnown, SizeAtMost:
+		return NewSizeMax(size.Size + 1)
+	default:
+		return size
+	}
+}
+
+func (tr *toResultsIter[T]) SeqOK() bool { return false }
+
+func (tr *toResultsIter[T]) Seq() iter.Seq[result.Result[T]] {
+	return Seq(tr)
+}
+
+// TryMap applies a fallible function mapping to each element of an iterator,
+// producing an [ErrIterator] over the results. Iteration stops at the first
+// error returned by mapping, at which point the source iterator is aborted
+// and the error is reported by the Err() method of the returned iterator. If
+// the source iterator itself reports errors through an Err() method, as an
+// [ErrIterator] does, its error is also reported once it is exhausted. E.g.
+//
+//	itr := iterator.TryMap(iterator.Of("1", "2", "x", "4"), strconv.Atoi)
+//	result := itr.Collect() // []int{1,2}
+//	err := itr.Err()        // strconv.Atoi: parsing "x": invalid syntax
+func TryMap[T any, U any](itr CoreIterator[T], mapping func(T) (U, error)) ErrIterator[U] {
+	return NewDefaultErrIterator(&tryMapIter[T, U]{base: itr, mapping: mapping})
+}
+
+// MapErr transforms the error reported by an [ErrIterator] by applying the
+// mapping function to it, which may be used to wrap the error with some
+// additional context. The mapping function is not called if there is no
+// error.
+func MapErr[T any](itr CoreErrIterator[T], mapping func(error) error) ErrIterator[T] {
+	return NewDefaultErrIterator(&mapErrIter[T]{CoreErrIterator: itr, mapping: mapping})
+}
+
+// FromResults converts an iterator of results into an [ErrIterator] over the
+// underlying values. Iteration stops at the first error result, at which point
+// the source iterator is aborted and the error is reported by the Err() method
+// of the returned iterator.
+func FromResults[T any](itr CoreIterator[result.Result[T]]) ErrIterator[T] {
+	return TryMap(itr, func(res result.Result[T]) (T, error) { return res.Return() })
+}
+
+// ToResults converts an [ErrIterator] into an iterator of results. Each
+// element is yielded as a successful result; if the source iterator stops on
+// error, the error is yielded as a final error result.
+func ToResults[T any](itr CoreErrIterator[T]) Iterator[result.Result[T]] {
+	return NewDefaultIterator(&toResultsIter[T]{base: itr})
+}
+
+// NewErr builds an [ErrIterator] from an [iter.Seq2] of values and errors, as
+// produced by many fallible sequence functions. Iteration stops at the first
+// non-nil error, which is reported by the Err() method of the returned
+// iterator.
+func NewErr[T any](seq iter.Seq2[T, error]) ErrIterator[T] {
+	return TryMap(AsKV(New2(seq)), func(kv KeyValue[T, error]) (T, error) { return kv.Key, kv.Value })
+}
+
+// SeqErr converts an [ErrIterator] into an [iter.Seq2] of values and errors.
+// Each element is yielded with a nil error; if the iterator stops on error, a
+// final pair is yielded consisting of the zero value and the error.
+func SeqErr[T any](itr CoreErrIterator[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for itr.Next() {
+			if !yield(itr.Value(), nil) {
+				itr.Abort()
+				return
+			}
+		}
+		if err := itr.Err(); err != nil {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
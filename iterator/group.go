@@ -0,0 +1,165 @@
+package iterator
+
+import "iter"
+
+// chunkByIter groups consecutive elements of an iterator that share the same
+// key. One element of lookahead is required to detect the end of a group; it
+// is held in pending until the following call to Next().
+type chunkByIter[T any, K comparable] struct {
+	base       CoreIterator[T]
+	keyFn      func(T) K
+	key        K
+	value      []T
+	pending    T
+	pendingKey K
+	hasPending bool
+	done       bool
+}
+
+func (cb *chunkByIter[T, K]) Next() bool {
+	if cb.done {
+		return false
+	}
+	if !cb.hasPending {
+		if !cb.base.Next() {
+			cb.done = true
+			return false
+		}
+		cb.pending = cb.base.Value()
+		cb.pendingKey = cb.keyFn(cb.pending)
+	}
+	key, group := cb.pendingKey, []T{cb.pending}
+	cb.hasPending = false
+	for cb.base.Next() {
+		v := cb.base.Value()
+		if k := cb.keyFn(v); k != key {
+			cb.pending, cb.pendingKey, cb.hasPending = v, k, true
+			break
+		}
+		group = append(group, v)
+	}
+	cb.key, cb.value = key, group
+	return true
+}
+
+func (cb *chunkByIter[T, K]) Key() K {
+	return cb.key
+}
+
+func (cb *chunkByIter[T, K]) Value() []T {
+	return cb.value
+}
+
+func (cb *chunkByIter[T, K]) Abort() {
+	cb.done = true
+	cb.hasPending = false
+	cb.base.Abort()
+}
+
+func (cb *chunkByIter[T, K]) Reset() {
+	cb.done = false
+	cb.hasPending = false
+	cb.base.Reset()
+}
+
+// Size is at most the number of elements remaining, since each group holds at
+// least one element.
+func (cb *chunkByIter[T, K]) Size() IteratorSize {
+	if cb.done {
+		return NewSize(0)
+	}
+	size := cb.base.Size()
+	if cb.hasPending {
+		size = addSize(size, NewSize(1))
+	}
+	switch size.Type {
+	case SizeKnown:
+		if size.Size == 0 {
+			return size
+		}
+		return NewSizeMax(size.Size)
+	case SizeInfinite:
+		return NewSizeUnknown()
+	default:
+		return size
+	}
+}
+
+func (cb *chunkByIter[T, K]) SeqOK() bool { return false }
+
+func (cb *chunkByIter[T, K]) Seq() iter.Seq[[]T] {
+	return Seq(cb)
+}
+
+func (cb *chunkByIter[T, K]) Seq2() iter.Seq2[K, []T] {
+	return func(yield func(K, []T) bool) {
+		for cb.Next() {
+			if !yield(cb.key, cb.value) {
+				cb.Abort()
+				break
+			}
+		}
+	}
+}
+
+// GroupBy collects the elements of an iterator into a map of slices, keyed by
+// the result of applying keyFn to each element. The elements in each slice
+// appear in the order in which they were produced by the iterator. If the
+// iterator is known to be of infinite size, this function will panic with
+// [ErrSizeInfinite]. E.g.
+//
+//	groups := iterator.GroupBy(iterator.Range(0, 5), func(n int) bool { return n%2 == 0 })
+//	// map[bool][]int{false: {1, 3}, true: {0, 2, 4}}
+func GroupBy[T any, K comparable](itr CoreIterator[T], keyFn func(T) K) map[K][]T {
+	if itr.Size().IsInfinite() {
+		panic(ErrSizeInfinite)
+	}
+	groups := make(map[K][]T)
+	if itr.SeqOK() {
+		for v := range itr.Seq() {
+			k := keyFn(v)
+			groups[k] = append(groups[k], v)
+		}
+	} else {
+		for itr.Next() {
+			v := itr.Value()
+			k := keyFn(v)
+			groups[k] = append(groups[k], v)
+		}
+	}
+	return groups
+}
+
+// CountBy counts the elements of an iterator by the result of applying keyFn
+// to each element, returning a map of the number of elements with each key. If
+// the iterator is known to be of infinite size, this function will panic with
+// [ErrSizeInfinite].
+func CountBy[T any, K comparable](itr CoreIterator[T], keyFn func(T) K) map[K]int {
+	if itr.Size().IsInfinite() {
+		panic(ErrSizeInfinite)
+	}
+	counts := make(map[K]int)
+	if itr.SeqOK() {
+		for v := range itr.Seq() {
+			counts[keyFn(v)]++
+		}
+	} else {
+		for itr.Next() {
+			counts[keyFn(itr.Value())]++
+		}
+	}
+	return counts
+}
+
+// ChunkBy builds an iterator that groups consecutive elements of an iterator
+// having the same key, as determined by applying keyFn to each element. Each
+// group is produced as a pair of the key and a newly allocated slice of the
+// elements in the group. Unlike [GroupBy], elements are streamed rather than
+// collected, and the same key may appear more than once if its elements are
+// not consecutive. E.g.
+//
+//	itr := iterator.ChunkBy(iterator.Of(1, 3, 2, 4, 5), func(n int) bool { return n%2 == 0 })
+//	result := itr.Collect2() // {false, {1, 3}}, {true, {2, 4}}, {false, {5}}
+func ChunkBy[T any, K comparable](itr CoreIterator[T], keyFn func(T) K) Iterator2[K, []T] {
+	return NewDefaultIterator2(&chunkByIter[T, K]{base: itr, keyFn: keyFn})
+}
@@ -162,6 +162,24 @@ type IteratorExtensions[T any] interface {
 	// the returned iterator is equivalent to the input iterator.
 	Take(n int) Iterator[T]
 
+	// TakeWhile returns a variant of the current iterator that returns
+	// elements for as long as they satisfy the predicate p, ending at the first
+	// element that does not.
+	TakeWhile(p func(T) bool) Iterator[T]
+
+	// Skip returns a variant of the current iterator that discards the first n
+	// elements and returns the rest.
+	Skip(n int) Iterator[T]
+
+	// SkipWhile returns a variant of the current iterator that discards
+	// elements for as long as they satisfy the predicate p, and returns the
+	// first element that does not, along with all the elements after it.
+	SkipWhile(p func(T) bool) Iterator[T]
+
+	// StepBy returns a variant of the current iterator that returns the first
+	// element, and then every step'th element after that.
+	StepBy(step int) Iterator[T]
+
 	// Any returns true if p returns true for at least one element in the iterator.
 	Any(p func(T) bool) bool
 
@@ -276,6 +294,85 @@ type Iterator2Extensions[K any, V any] interface {
 	// exactly n element pairs, the returned iterator is equivalent to the input
 	// iterator.
 	Take2(int) Iterator2[K, V]
+
+	// TakeWhile2 returns a variant of the current iterator that returns pairs
+	// of elements for as long as they satisfy the predicate p, ending at the
+	// first pair that does not.
+	TakeWhile2(p func(K, V) bool) Iterator2[K, V]
+
+	// Skip2 returns a variant of the current iterator that discards the first
+	// n pairs of elements and returns the rest.
+	Skip2(n int) Iterator2[K, V]
+
+	// SkipWhile2 returns a variant of the current iterator that discards pairs
+	// of elements for as long as they satisfy the predicate p, and returns the
+	// first pair that does not, along with all the pairs after it.
+	SkipWhile2(p func(K, V) bool) Iterator2[K, V]
+
+	// StepBy2 returns a variant of the current iterator that returns the first
+	// pair of elements, and then every step'th pair after that.
+	StepBy2(step int) Iterator2[K, V]
+}
+
+// CoreErrIterator extends [CoreIterator] with a method to report an error that
+// caused iteration to stop early, in the style of [bufio.Scanner].
+type CoreErrIterator[T any] interface {
+	CoreIterator[T]
+	// Err returns the error, if any, that caused the iterator to stop. It
+	// returns nil if the iterator has not stopped, or if it stopped because
+	// there were no more elements. It should be checked once Next() has
+	// returned false, or once a range loop over Seq() has ended.
+	Err() error
+}
+
+// CoreDoubleEndedIterator extends [CoreIterator] with a method to consume
+// elements from the back of the iterator as well as from the front.
+type CoreDoubleEndedIterator[T any] interface {
+	CoreIterator[T]
+	// NextBack sets the iterator's current value to be the last, and
+	// preceding, elements that have not yet been consumed from either end.
+	// False is returned only when there are no more elements. Calls to Next()
+	// and NextBack() may be interleaved; iteration ends when the two meet.
+	NextBack() bool
+}
+
+// CoreRandomAccessIterator extends [CoreDoubleEndedIterator] with methods to
+// access the remaining elements of the iterator by position in constant time,
+// without consuming them. Positions are relative to the remaining elements, so
+// that position 0 is the element that would be produced by the next call to
+// Next().
+type CoreRandomAccessIterator[T any] interface {
+	CoreDoubleEndedIterator[T]
+	// Len returns the number of elements remaining.
+	Len() int
+	// At returns the element at position i among the remaining elements. It
+	// panics with [ErrInvalidIteratorRange] if i is not less than Len().
+	At(i int) T
+	// Slice returns a new iterator over the remaining elements from position i
+	// up to, but not including, position j. It panics with
+	// [ErrInvalidIteratorRange] unless 0 <= i <= j <= Len().
+	Slice(i, j int) RandomAccessIterator[T]
+}
+
+// Splittable is implemented by iterators whose remaining elements can be
+// divided between two iterators, so that they may be consumed in parallel by
+// functions such as [ParFold]. The iterators returned by [Slice], [Of], the
+// range functions such as [Range], and maps.Iter implement it.
+type Splittable[T any] interface {
+	// TrySplit attempts to divide the remaining elements in two. On success,
+	// it returns a new iterator over a leading portion of the elements, which
+	// this iterator will no longer produce, and true. If the elements cannot
+	// usefully be divided, it returns false.
+	TrySplit() (CoreIterator[T], bool)
+}
+
+// CoreDoubleEndedIterator2 is the [CoreIterator2] equivalent of
+// [CoreDoubleEndedIterator].
+type CoreDoubleEndedIterator2[K any, V any] interface {
+	CoreIterator2[K, V]
+	// NextBack sets the iterator's current key and value to be the last, and
+	// preceding, pairs that have not yet been consumed from either end.
+	NextBack() bool
 }
 
 // Top level iterator types
@@ -322,3 +419,45 @@ type MutableIterator2[K any, V any] interface {
 	IteratorExtensions[V]
 	Iterator2Extensions[K, V]
 }
+
+// ErrIterator is a generic iterator type whose iteration may fail. Iteration
+// stops at the first error encountered, which is then reported by Err(). It
+// consists of methods from [CoreErrIterator], plus the ones from
+// [IteratorExtensions].
+type ErrIterator[T any] interface {
+	CoreErrIterator[T]
+	IteratorExtensions[T]
+}
+
+// DoubleEndedIterator is a generic iterator type whose elements may be
+// consumed from either end. It consists of methods from
+// [CoreDoubleEndedIterator], plus the ones from [IteratorExtensions]. The
+// iterators returned by [Slice], [Of] and the range functions such as [Range]
+// and [RangeBy] implement this interface, and may be converted to it with a
+// type assertion; [Rev] makes use of it where available.
+type DoubleEndedIterator[T any] interface {
+	CoreDoubleEndedIterator[T]
+	IteratorExtensions[T]
+}
+
+// RandomAccessIterator is a generic iterator type whose remaining elements may
+// be accessed by position. It consists of methods from
+// [CoreRandomAccessIterator], plus the ones from [IteratorExtensions]. The
+// iterators returned by [Slice], [Of] and the range functions such as [Range]
+// and [RangeBy] implement this interface. Adapters such as [Skip], [Take] and
+// [StepBy] detect it, and produce random access iterators in turn, so that
+// skipping elements takes constant time.
+type RandomAccessIterator[T any] interface {
+	CoreRandomAccessIterator[T]
+	IteratorExtensions[T]
+}
+
+// DoubleEndedIterator2 is a generic iterator type whose key and value pairs
+// may be consumed from either end. It consists of methods from
+// [CoreDoubleEndedIterator2], plus the ones from [IteratorExtensions] and
+// [Iterator2Extensions].
+type DoubleEndedIterator2[K any, V any] interface {
+	CoreDoubleEndedIterator2[K, V]
+	IteratorExtensions[V]
+	Iterator2Extensions[K, V]
+}
@@ -125,6 +125,32 @@ func (di DefaultIterator[T]) Take(n int) Iterator[T] {
 	return Take(n, di)
 }
 
+// TakeWhile returns a variant of the current iterator that returns elements
+// for as long as they satisfy the predicate p, ending at the first element
+// that does not.
+func (di DefaultIterator[T]) TakeWhile(p func(T) bool) Iterator[T] {
+	return TakeWhile(di, p)
+}
+
+// Skip returns a variant of the current iterator that discards the first n
+// elements and returns the rest.
+func (di DefaultIterator[T]) Skip(n int) Iterator[T] {
+	return Skip(n, di)
+}
+
+// SkipWhile returns a variant of the current iterator that discards elements
+// for as long as they satisfy the predicate p, and returns the first element
+// that does not, along with all the elements after it.
+func (di DefaultIterator[T]) SkipWhile(p func(T) bool) Iterator[T] {
+	return SkipWhile(di, p)
+}
+
+// StepBy returns a variant of the current iterator that returns the first
+// element, and then every step'th element after that.
+func (di DefaultIterator[T]) StepBy(step int) Iterator[T] {
+	return StepBy(step, di)
+}
+
 // All returns true if p returns true for all the elements in the iterator.
 // This method short circuits and does not execute in constant time; the
 // iterator is aborted after the first value for which the predicate returns
@@ -285,6 +311,32 @@ func (di2 DefaultIterator2[K, V]) Take2(n int) Iterator2[K, V] {
 	return Take2(n, di2.CoreIterator2)
 }
 
+// TakeWhile2 returns a variant of the current iterator that returns pairs of
+// elements for as long as they satisfy the predicate p, ending at the first
+// pair that does not.
+func (di2 DefaultIterator2[K, V]) TakeWhile2(p func(K, V) bool) Iterator2[K, V] {
+	return TakeWhile2(di2.CoreIterator2, p)
+}
+
+// Skip2 returns a variant of the current iterator that discards the first n
+// pairs of elements and returns the rest.
+func (di2 DefaultIterator2[K, V]) Skip2(n int) Iterator2[K, V] {
+	return Skip2(n, di2.CoreIterator2)
+}
+
+// SkipWhile2 returns a variant of the current iterator that discards pairs of
+// elements for as long as they satisfy the predicate p, and returns the first
+// pair that does not, along with all the pairs after it.
+func (di2 DefaultIterator2[K, V]) SkipWhile2(p func(K, V) bool) Iterator2[K, V] {
+	return SkipWhile2(di2.CoreIterator2, p)
+}
+
+// StepBy2 returns a variant of the current iterator that returns the first
+// pair of elements, and then every step'th pair after that.
+func (di2 DefaultIterator2[K, V]) StepBy2(step int) Iterator2[K, V] {
+	return StepBy2(step, di2.CoreIterator2)
+}
+
 // Filter2 is a filtering method that creates a new iterator which contains
 // a subset of element pairs contained by the current one. This function
 // takes a predicate function p and only element pairs for which this
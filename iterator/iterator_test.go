@@ -1,20 +1,29 @@
 package iterator_test
 
 import (
+	"cmp"
+	"context"
+	"errors"
 	"fmt"
 	"iter"
+	"math"
+	"runtime"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	eh "github.com/robdavid/genutil-go/errors/handler"
 	"github.com/robdavid/genutil-go/errors/result"
 	"github.com/robdavid/genutil-go/functions"
 	"github.com/robdavid/genutil-go/iterator"
 	"github.com/robdavid/genutil-go/maps"
+	"github.com/robdavid/genutil-go/opt"
 	"github.com/robdavid/genutil-go/ordered"
 	"github.com/robdavid/genutil-go/slices"
+	"github.com/robdavid/genutil-go/tuple"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/exp/constraints"
@@ -1481,6 +1490,23 @@ func TestGeneratorIterAbort(t *testing.T) {
 	assert.Equal(t, expected, actual)
 }
 
+func TestGeneratorConcurrentAbort(t *testing.T) {
+	for _, opts := range [][]iterator.GenOption{nil, {iterator.GenBatch(4)}} {
+		gen := iterator.Generate(countGen, opts...)
+		assert.True(t, gen.Next())
+		var wg sync.WaitGroup
+		for range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				gen.Abort()
+			}()
+		}
+		wg.Wait()
+		assert.False(t, gen.Next())
+	}
+}
+
 func TestGeneratorMap(t *testing.T) {
 	gen := iterator.Generate(func(c iterator.Consumer[int]) {
 		for i := range 10 {
@@ -1825,3 +1851,1770 @@ func TestMaxByFoldMethodSeq(t *testing.T) {
 	acc := iterator.Fold(c, nil, app)
 	assert.Equal(t, slices.Range(0, 5), acc)
 }
+
+func TestParMap(t *testing.T) {
+	const size = 1000
+	itr := iterator.ParMap(iterator.Range(0, size), func(n int) int { return n * 2 }, iterator.ParMaxCpu(4))
+	assert.True(t, itr.Size().IsKnownToBe(size))
+	expected := iterator.Map(iterator.Range(0, size), func(n int) int { return n * 2 }).Collect()
+	assert.Equal(t, expected, itr.Collect())
+	assert.True(t, itr.Size().IsKnownToBe(0))
+}
+
+func TestParMapUnordered(t *testing.T) {
+	const size = 1000
+	actual := iterator.ParMap(iterator.Range(0, size), func(n int) int { return n * 2 },
+		iterator.ParMaxCpu(4), iterator.ParOrdered(false)).Collect()
+	sort.Ints(actual)
+	expected := iterator.Map(iterator.Range(0, size), func(n int) int { return n * 2 }).Collect()
+	assert.Equal(t, expected, actual)
+}
+
+func TestParMapSlicesOption(t *testing.T) {
+	actual := iterator.ParMap(iterator.Range(0, 10), strconv.Itoa, slices.ParMaxCpu(2)).Collect()
+	assert.Equal(t, []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9"}, actual)
+}
+
+func TestParMapSeq(t *testing.T) {
+	itr := iterator.ParMap(fibSeq(), func(n int) int { return n * 2 })
+	var actual []int
+	for v := range itr.Seq() {
+		if len(actual) == 5 {
+			break
+		}
+		actual = append(actual, v)
+	}
+	assert.Equal(t, []int{2, 2, 4, 6, 10}, actual)
+}
+
+func TestParFilter(t *testing.T) {
+	itr := iterator.ParFilter(iterator.Range(0, 100), func(n int) bool { return n%3 == 0 })
+	assert.True(t, itr.Size().IsMaxKnownToBe(100))
+	assert.Equal(t, iterator.RangeBy(0, 100, 3).Collect(), itr.Collect())
+}
+
+func TestParFilterMap(t *testing.T) {
+	itr := iterator.ParFilterMap(iterator.Range(0, 10), func(n int) (string, bool) {
+		return strconv.Itoa(n), n%2 == 0
+	})
+	assert.Equal(t, []string{"0", "2", "4", "6", "8"}, itr.Collect())
+}
+
+func TestParMapAbort(t *testing.T) {
+	const size = 1000
+	source := repeatIter(size, 1)
+	itr := iterator.ParMap(source, func(n int) int { return n + 1 }, iterator.ParMaxCpu(2))
+	for range 10 {
+		require.True(t, itr.Next())
+		assert.Equal(t, 2, itr.Value())
+	}
+	itr.Abort()
+	assert.False(t, itr.Next())
+	assert.True(t, source.Size().IsKnownToBe(0))
+}
+
+func TestParMapAbortStopsMapping(t *testing.T) {
+	sources := []iterator.Iterator[int]{iterator.Range(0, 1000), repeatIter(1000, 1)}
+	for _, source := range sources {
+		var mu sync.Mutex
+		calls, running := 0, 0
+		itr := iterator.ParMap(source, func(n int) int {
+			mu.Lock()
+			calls++
+			running++
+			mu.Unlock()
+			time.Sleep(time.Millisecond)
+			mu.Lock()
+			running--
+			mu.Unlock()
+			return n
+		}, iterator.ParMaxCpu(4))
+		for range 10 {
+			require.True(t, itr.Next())
+		}
+		itr.Abort()
+		mu.Lock()
+		assert.Equal(t, 0, running)
+		aborted := calls
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		mu.Lock()
+		assert.Equal(t, aborted, calls)
+		mu.Unlock()
+	}
+}
+
+func TestParMapReset(t *testing.T) {
+	itr := iterator.ParMap(iterator.Range(0, 100), func(n int) int { return -n })
+	require.True(t, itr.Next())
+	assert.Equal(t, 0, itr.Value())
+	require.True(t, itr.Next())
+	itr.Reset()
+	assert.Equal(t, iterator.Range(0, -100).Collect(), itr.Collect())
+}
+
+func TestParMapPanic(t *testing.T) {
+	itr := iterator.ParMap(iterator.Range(0, 100), func(n int) int {
+		if n == 50 {
+			panic(fmt.Errorf("cannot map %d", n))
+		}
+		return n
+	})
+	defer func() {
+		p := recover()
+		require.NotNil(t, p)
+		var pp iterator.ParPanic
+		require.ErrorAs(t, p.(error), &pp)
+		assert.EqualError(t, errors.Unwrap(pp), "cannot map 50")
+	}()
+	itr.Collect()
+}
+
+func TestParMapInvalidCpu(t *testing.T) {
+	assert.Panics(t, func() { iterator.ParMap(iterator.Range(0, 10), functions.Id[int], iterator.ParMaxCpu(0)) })
+}
+
+func TestZip(t *testing.T) {
+	itr := iterator.Zip(iterator.Of("zero", "one", "two", "three"), iterator.Range(0, 3))
+	assert.True(t, itr.Size().IsKnownToBe(3))
+	expected := []iterator.KeyValue[string, int]{{"zero", 0}, {"one", 1}, {"two", 2}}
+	assert.Equal(t, expected, itr.Collect2())
+}
+
+func TestZipSeq2(t *testing.T) {
+	itr := iterator.Zip(fibSeq(), iterator.Range(0, 5))
+	assert.True(t, itr.Size().IsKnownToBe(5))
+	var keys, values []int
+	for k, v := range itr.Seq2() {
+		keys = append(keys, k)
+		values = append(values, v)
+	}
+	assert.Equal(t, []int{1, 1, 2, 3, 5}, keys)
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, values)
+}
+
+func TestZipSizeCollect(t *testing.T) {
+	itr := iterator.Zip(iterator.Range(0, 100), iterator.Range(0, 1000))
+	c := itr.Collect2()
+	assert.Equal(t, 100, len(c))
+	assert.Equal(t, 100, cap(c))
+}
+
+func TestZipWith(t *testing.T) {
+	itr := iterator.ZipWith(iterator.Of("a", "b", "c"), iterator.Range(1, 10), func(s string, n int) string {
+		return strings.Repeat(s, n)
+	})
+	assert.True(t, itr.Size().IsKnownToBe(3))
+	assert.Equal(t, []string{"a", "bb", "ccc"}, itr.Collect())
+}
+
+func TestZipLongest(t *testing.T) {
+	itr := iterator.ZipLongest(iterator.Of("a", "b", "c"), iterator.Range(0, 5))
+	assert.True(t, itr.Size().IsKnownToBe(5))
+	var keys []string
+	var values []int
+	for k, v := range itr.Seq2() {
+		keys = append(keys, k.GetOr("-"))
+		values = append(values, v.GetOr(-1))
+	}
+	assert.Equal(t, []string{"a", "b", "c", "-", "-"}, keys)
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, values)
+}
+
+func TestZipLongestInfinite(t *testing.T) {
+	itr := iterator.ZipLongest(iterator.Of(1, 2), fibSeq())
+	assert.True(t, itr.Size().IsInfinite())
+	itr.Abort()
+	assert.False(t, itr.Next())
+}
+
+func TestUnzip(t *testing.T) {
+	keys, values := iterator.Unzip(iterator.Of("a", "b", "c").Enumerate())
+	assert.True(t, keys.Size().IsKnownToBe(3))
+	assert.Equal(t, []int{0, 1, 2}, keys.Collect())
+	assert.True(t, values.Size().IsKnownToBe(3))
+	assert.Equal(t, []string{"a", "b", "c"}, values.Collect())
+}
+
+func TestUnzipInterleaved(t *testing.T) {
+	keys, values := iterator.Unzip(iterator.Zip(iterator.Range(0, 10), iterator.Range(10, 20)))
+	require.True(t, values.Next())
+	assert.Equal(t, 10, values.Value())
+	for i := range 3 {
+		require.True(t, keys.Next())
+		assert.Equal(t, i, keys.Value())
+	}
+	assert.True(t, values.Size().IsKnownToBe(9))
+	assert.True(t, keys.Size().IsKnownToBe(7))
+	assert.Equal(t, iterator.Range(11, 20).Collect(), values.Collect())
+	assert.Equal(t, iterator.Range(3, 10).Collect(), keys.Collect())
+}
+
+func TestUnzipAbort(t *testing.T) {
+	source := iterator.Range(0, 10).Enumerate()
+	keys, values := iterator.Unzip(source)
+	values.Abort()
+	assert.False(t, values.Next())
+	assert.Equal(t, iterator.Range(0, 10).Collect(), keys.Collect())
+	keys.Reset()
+	require.True(t, keys.Next())
+	keys.Abort()
+	values.Abort()
+	assert.True(t, source.Size().IsKnownToBe(0))
+}
+
+func TestChunk(t *testing.T) {
+	itr := iterator.Chunk(iterator.Range(0, 10), 3)
+	assert.True(t, itr.Size().IsKnownToBe(4))
+	assert.Equal(t, [][]int{{0, 1, 2}, {3, 4, 5}, {6, 7, 8}, {9}}, itr.Collect())
+}
+
+func TestChunkSeq(t *testing.T) {
+	itr := iterator.Chunk(iterator.New(rangeSeq(0, 6, 1)), 2)
+	assert.True(t, itr.SeqOK())
+	var actual [][]int
+	for chunk := range itr.Seq() {
+		actual = append(actual, chunk)
+	}
+	assert.Equal(t, [][]int{{0, 1}, {2, 3}, {4, 5}}, actual)
+}
+
+func TestChunkSizes(t *testing.T) {
+	assert.True(t, iterator.Chunk(iterator.Range(0, 9), 3).Size().IsKnownToBe(3))
+	assert.True(t, iterator.Chunk(iterator.Range(0, 0), 3).Size().IsKnownToBe(0))
+	assert.True(t, iterator.Chunk(fibSeq(), 3).Size().IsInfinite())
+	assert.True(t, iterator.Chunk(iterator.Range(0, 10).Filter(func(int) bool { return true }), 4).Size().IsMaxKnownToBe(3))
+	assert.Panics(t, func() { iterator.Chunk(iterator.Range(0, 10), 0) })
+}
+
+func TestChunkAbort(t *testing.T) {
+	source := iterator.Range(0, 10)
+	itr := iterator.Chunk(source, 3)
+	require.True(t, itr.Next())
+	itr.Abort()
+	assert.False(t, itr.Next())
+	assert.True(t, source.Size().IsKnownToBe(0))
+}
+
+func TestWindow(t *testing.T) {
+	itr := iterator.Window(iterator.Range(0, 5), 3, 1)
+	assert.True(t, itr.Size().IsKnownToBe(3))
+	assert.Equal(t, [][]int{{0, 1, 2}, {1, 2, 3}, {2, 3, 4}}, itr.Collect())
+}
+
+func TestWindowStep(t *testing.T) {
+	itr := iterator.Window(iterator.Range(0, 10), 3, 2)
+	assert.True(t, itr.Size().IsKnownToBe(4))
+	require.True(t, itr.Next())
+	assert.True(t, itr.Size().IsKnownToBe(3))
+	assert.Equal(t, [][]int{{2, 3, 4}, {4, 5, 6}, {6, 7, 8}}, itr.Collect())
+}
+
+func TestWindowLargeStep(t *testing.T) {
+	itr := iterator.Window(iterator.Range(0, 10), 2, 4)
+	assert.True(t, itr.Size().IsKnownToBe(3))
+	assert.Equal(t, [][]int{{0, 1}, {4, 5}, {8, 9}}, itr.Collect())
+	assert.Empty(t, iterator.Window(iterator.Range(0, 2), 3, 1).Collect())
+}
+
+func TestWindowReset(t *testing.T) {
+	itr := iterator.Window(iterator.Range(0, 4), 2, 1)
+	require.True(t, itr.Next())
+	itr.Reset()
+	assert.Equal(t, [][]int{{0, 1}, {1, 2}, {2, 3}}, itr.Collect())
+}
+
+func TestBatchCount(t *testing.T) {
+	itr := iterator.Batch(iterator.Range(0, 10), 4, 0)
+	assert.True(t, itr.Size().IsMaxKnownToBe(10))
+	assert.Equal(t, [][]int{{0, 1, 2, 3}, {4, 5, 6, 7}, {8, 9}}, itr.Collect())
+	assert.True(t, itr.Size().IsKnownToBe(0))
+}
+
+func TestBatchTimeout(t *testing.T) {
+	gen := iterator.Generate(func(c iterator.Consumer[int]) {
+		for i := range 6 {
+			if i == 3 {
+				time.Sleep(100 * time.Millisecond)
+			}
+			c.Yield(i)
+		}
+	})
+	itr := iterator.Batch(gen, 10, 20*time.Millisecond)
+	assert.Equal(t, [][]int{{0, 1, 2}, {3, 4, 5}}, itr.Collect())
+}
+
+func TestBatchAbort(t *testing.T) {
+	source := iterator.Range(0, 1000)
+	itr := iterator.Batch(source, 10, time.Second)
+	require.True(t, itr.Next())
+	assert.Equal(t, iterator.Range(0, 10).Collect(), itr.Value())
+	assert.True(t, itr.Size().IsMaxKnownToBe(990))
+	itr.Abort()
+	assert.False(t, itr.Next())
+}
+
+func TestBatchAbortWaits(t *testing.T) {
+	stopped := false
+	source := iterator.New(func(yield func(int) bool) {
+		defer func() { stopped = true }()
+		for i := 0; yield(i); i++ {
+		}
+	})
+	itr := iterator.Batch(source, 10, 0)
+	require.True(t, itr.Next())
+	itr.Abort()
+	// The source has been released by the time Abort returns
+	assert.True(t, stopped)
+	assert.False(t, itr.Next())
+}
+
+func TestConcat(t *testing.T) {
+	itr := iterator.Concat(iterator.Range(0, 3), iterator.Of(10, 11), iterator.Empty[int](), iterator.Range(20, 22))
+	assert.True(t, itr.Size().IsKnownToBe(7))
+	assert.False(t, itr.SeqOK())
+	assert.Equal(t, []int{0, 1, 2, 10, 11, 20, 21}, itr.Collect())
+	assert.True(t, itr.Size().IsKnownToBe(0))
+}
+
+func TestConcatSeq(t *testing.T) {
+	itr := iterator.Concat(iterator.New(rangeSeq(0, 3, 1)), slices.Iter([]int{5, 6}))
+	assert.True(t, itr.SeqOK())
+	var actual []int
+	for v := range itr.Seq() {
+		actual = append(actual, v)
+	}
+	assert.Equal(t, []int{0, 1, 2, 5, 6}, actual)
+}
+
+func TestConcatSizes(t *testing.T) {
+	filtered := iterator.Range(0, 5).Filter(func(int) bool { return true })
+	assert.True(t, iterator.Concat(iterator.Range(0, 3), filtered).Size().IsMaxKnownToBe(8))
+	assert.True(t, iterator.Concat(iterator.Range(0, 3), fibSeq()).Size().IsInfinite())
+	assert.True(t, iterator.Concat(iterator.Range(0, 3), iterator.New(rangeSeq(0, 3, 1))).Size().IsUnknown())
+	assert.True(t, iterator.Concat[int]().Size().IsKnownToBe(0))
+}
+
+func TestConcatAbort(t *testing.T) {
+	first, second := iterator.Range(0, 3), iterator.Range(0, 3)
+	itr := iterator.Concat(first, second)
+	require.True(t, itr.Next())
+	itr.Abort()
+	assert.False(t, itr.Next())
+	assert.True(t, first.Size().IsKnownToBe(0))
+	assert.True(t, second.Size().IsKnownToBe(0))
+	itr.Reset()
+	assert.Equal(t, []int{0, 1, 2, 0, 1, 2}, itr.Collect())
+}
+
+func TestFlatMap(t *testing.T) {
+	itr := iterator.FlatMap(iterator.Range(1, 4), func(n int) iterator.CoreIterator[int] {
+		return iterator.Range(0, n)
+	})
+	assert.True(t, itr.Size().IsUnknown())
+	assert.Equal(t, []int{0, 0, 1, 0, 1, 2}, itr.Collect())
+}
+
+func TestFlatMapSeq(t *testing.T) {
+	itr := iterator.FlatMap(iterator.New(rangeSeq(1, 4, 1)), func(n int) iterator.CoreIterator[string] {
+		return iterator.Map(iterator.Range(0, n), strconv.Itoa)
+	})
+	assert.True(t, itr.SeqOK())
+	var actual []string
+	for v := range itr.Seq() {
+		if len(actual) == 4 {
+			break
+		}
+		actual = append(actual, v)
+	}
+	assert.Equal(t, []string{"0", "0", "1", "0"}, actual)
+}
+
+func TestFlatMapSize(t *testing.T) {
+	itr := iterator.FlatMap(iterator.Of(3), func(n int) iterator.CoreIterator[int] {
+		return iterator.Range(0, n)
+	})
+	require.True(t, itr.Next())
+	assert.True(t, itr.Size().IsKnownToBe(2))
+}
+
+func TestFlatten(t *testing.T) {
+	itr := iterator.Flatten(iterator.Of(iterator.Range(0, 2), iterator.Range(5, 7)))
+	assert.Equal(t, []int{0, 1, 5, 6}, itr.Collect())
+}
+
+func TestSkip(t *testing.T) {
+	itr := iterator.Range(0, 10).Skip(4)
+	assert.True(t, itr.Size().IsKnownToBe(6))
+	assert.Equal(t, slices.Range(4, 10), itr.Collect())
+	itr.Reset()
+	assert.True(t, itr.Next())
+	assert.Equal(t, 4, itr.Value())
+	assert.True(t, itr.Size().IsKnownToBe(5))
+}
+
+func TestSkipMore(t *testing.T) {
+	itr := iterator.Skip(10, iterator.Of(1, 2, 3))
+	assert.True(t, itr.Size().IsKnownToBe(0))
+	assert.Empty(t, itr.Collect())
+}
+
+func TestSkipSeq(t *testing.T) {
+	itr := iterator.New(rangeSeq(0, 10, 1)).Skip(7)
+	assert.True(t, itr.SeqOK())
+	var actual []int
+	for v := range itr.Seq() {
+		actual = append(actual, v)
+	}
+	assert.Equal(t, []int{7, 8, 9}, actual)
+}
+
+func TestSkipSizes(t *testing.T) {
+	assert.True(t, iterator.Range(0, 10).Filter(func(int) bool { return true }).Skip(3).Size().IsMaxKnownToBe(7))
+	assert.True(t, fibSeq().Skip(3).Size().IsInfinite())
+	assert.Equal(t, []int{3, 5, 8}, fibSeq().Skip(3).Take(3).Collect())
+}
+
+func TestSkip2(t *testing.T) {
+	itr := iterator.Range(0, 5).Enumerate().Skip2(3)
+	assert.True(t, itr.Size().IsKnownToBe(2))
+	assert.Equal(t, []iterator.KeyValue[int, int]{{3, 3}, {4, 4}}, itr.Collect2())
+}
+
+func TestSkip2Seq2(t *testing.T) {
+	itr := testSeqIter2(0, 5, func(n int) int { return n * 2 }).Skip2(3)
+	actual := make(map[int]int)
+	for k, v := range itr.Seq2() {
+		actual[k] = v
+	}
+	assert.Equal(t, map[int]int{3: 6, 4: 8}, actual)
+}
+
+func TestSkipWhile(t *testing.T) {
+	itr := iterator.Of(1, 2, 5, 1, 7).SkipWhile(func(n int) bool { return n < 3 })
+	assert.True(t, itr.Size().IsMaxKnownToBe(5))
+	assert.True(t, itr.Next())
+	assert.Equal(t, 5, itr.Value())
+	assert.True(t, itr.Size().IsKnownToBe(2))
+	assert.Equal(t, []int{1, 7}, itr.Collect())
+	itr.Reset()
+	assert.Equal(t, []int{5, 1, 7}, itr.Collect())
+}
+
+func TestSkipWhileSeq(t *testing.T) {
+	itr := iterator.New(rangeSeq(0, 10, 1)).SkipWhile(func(n int) bool { return n < 8 })
+	var actual []int
+	for v := range itr.Seq() {
+		actual = append(actual, v)
+	}
+	assert.Equal(t, []int{8, 9}, actual)
+}
+
+func TestSkipWhile2(t *testing.T) {
+	itr := iterator.Of(5, 6, 7, 8).Enumerate().SkipWhile2(func(k, v int) bool { return k+v < 9 })
+	assert.Equal(t, []iterator.KeyValue[int, int]{{2, 7}, {3, 8}}, itr.Collect2())
+	seqItr := testSeqIter2(0, 5, func(n int) int { return n * 2 }).SkipWhile2(func(k, v int) bool { return k < 3 })
+	var values []int
+	for v := range seqItr.Seq() {
+		values = append(values, v)
+	}
+	assert.Equal(t, []int{6, 8}, values)
+}
+
+func TestTakeWhile(t *testing.T) {
+	base := iterator.Range(0, 10)
+	itr := base.TakeWhile(func(n int) bool { return n < 3 })
+	assert.True(t, itr.Size().IsMaxKnownToBe(10))
+	assert.Equal(t, []int{0, 1, 2}, itr.Collect())
+	assert.True(t, itr.Size().IsKnownToBe(0))
+	assert.True(t, base.Size().IsKnownToBe(0)) // base is aborted
+	itr.Reset()
+	assert.Equal(t, []int{0, 1, 2}, itr.Collect())
+}
+
+func TestTakeWhileInfinite(t *testing.T) {
+	itr := fibSeq().TakeWhile(func(n int) bool { return n < 20 })
+	assert.True(t, itr.Size().IsUnknown())
+	assert.True(t, itr.SeqOK())
+	var actual []int
+	for v := range itr.Seq() {
+		actual = append(actual, v)
+	}
+	assert.Equal(t, []int{1, 1, 2, 3, 5, 8, 13}, actual)
+	assert.False(t, itr.SeqOK())
+	assert.Empty(t, itr.Collect())
+}
+
+func TestTakeWhile2(t *testing.T) {
+	itr := iterator.Of(5, 6, 7, 8).Enumerate().TakeWhile2(func(k, v int) bool { return k+v < 9 })
+	assert.Equal(t, []iterator.KeyValue[int, int]{{0, 5}, {1, 6}}, itr.Collect2())
+	seqItr := testSeqIter2(0, 5, func(n int) int { return n * 2 }).TakeWhile2(func(k, v int) bool { return k < 3 })
+	actual := make(map[int]int)
+	for k, v := range seqItr.Seq2() {
+		actual[k] = v
+	}
+	assert.Equal(t, map[int]int{0: 0, 1: 2, 2: 4}, actual)
+}
+
+func TestStepBy(t *testing.T) {
+	itr := iterator.Range(0, 10).StepBy(3)
+	assert.True(t, itr.Size().IsKnownToBe(4))
+	assert.True(t, itr.Next())
+	assert.Equal(t, 0, itr.Value())
+	assert.True(t, itr.Size().IsKnownToBe(3))
+	assert.Equal(t, []int{3, 6, 9}, itr.Collect())
+	itr.Reset()
+	assert.Equal(t, []int{0, 3, 6, 9}, itr.Collect())
+}
+
+func TestStepBySizes(t *testing.T) {
+	for n := range 10 {
+		for step := 1; step < 5; step++ {
+			itr := iterator.Range(0, n).StepBy(step)
+			for remain := len(iterator.Range(0, n).StepBy(step).Collect()); remain > 0; remain-- {
+				assert.True(t, itr.Size().IsKnownToBe(remain))
+				require.True(t, itr.Next())
+			}
+			assert.True(t, itr.Size().IsKnownToBe(0))
+			assert.False(t, itr.Next())
+		}
+	}
+}
+
+func TestStepBySeq(t *testing.T) {
+	itr := iterator.New(rangeSeq(0, 10, 1)).StepBy(4)
+	var actual []int
+	for v := range itr.Seq() {
+		actual = append(actual, v)
+	}
+	assert.Equal(t, []int{0, 4, 8}, actual)
+}
+
+func TestStepByInvalid(t *testing.T) {
+	assert.PanicsWithError(t, "invalid iterator range: step 0 is less than 1", func() { iterator.Range(0, 10).StepBy(0) })
+}
+
+func TestStepBy2(t *testing.T) {
+	itr := iterator.Range(0, 5).Enumerate().StepBy2(2)
+	assert.True(t, itr.Size().IsKnownToBe(3))
+	assert.Equal(t, []iterator.KeyValue[int, int]{{0, 0}, {2, 2}, {4, 4}}, itr.Collect2())
+	seqItr := testSeqIter2(0, 5, func(n int) int { return n * 2 }).StepBy2(2)
+	actual := make(map[int]int)
+	for k, v := range seqItr.Seq2() {
+		actual[k] = v
+	}
+	assert.Equal(t, map[int]int{0: 0, 2: 4, 4: 8}, actual)
+}
+
+func TestGroupBy(t *testing.T) {
+	even := func(n int) bool { return n%2 == 0 }
+	assert.Equal(t, map[bool][]int{false: {1, 3}, true: {0, 2, 4}}, iterator.GroupBy(iterator.Range(0, 5), even))
+	assert.Equal(t, map[bool][]int{false: {1, 3}, true: {0, 2, 4}}, iterator.GroupBy(iterator.New(rangeSeq(0, 5, 1)), even))
+	assert.Empty(t, iterator.GroupBy(iterator.Empty[int](), even))
+	assert.PanicsWithError(t, iterator.ErrSizeInfinite.Error(), func() { iterator.GroupBy(fibSeq(), even) })
+}
+
+func TestCountBy(t *testing.T) {
+	words := iterator.Of("pear", "apple", "plum", "avocado", "banana", "peach")
+	assert.Equal(t, map[byte]int{'p': 3, 'a': 2, 'b': 1}, iterator.CountBy(words, func(w string) byte { return w[0] }))
+}
+
+func TestChunkBy(t *testing.T) {
+	even := func(n int) bool { return n%2 == 0 }
+	itr := iterator.ChunkBy(iterator.Of(1, 3, 2, 4, 6, 5), even)
+	assert.True(t, itr.Size().IsMaxKnownToBe(6))
+	require.True(t, itr.Next())
+	assert.Equal(t, false, itr.Key())
+	assert.Equal(t, []int{1, 3}, itr.Value())
+	assert.True(t, itr.Size().IsMaxKnownToBe(4))
+	assert.Equal(t, []iterator.KeyValue[bool, []int]{{true, []int{2, 4, 6}}, {false, []int{5}}}, itr.Collect2())
+	assert.True(t, itr.Size().IsKnownToBe(0))
+	itr.Reset()
+	assert.Equal(t, [][]int{{1, 3}, {2, 4, 6}, {5}}, itr.Collect())
+}
+
+func TestChunkBySeq2(t *testing.T) {
+	itr := iterator.ChunkBy(iterator.New(rangeSeq(0, 10, 1)), func(n int) int { return n / 4 })
+	assert.True(t, itr.Size().IsUnknown())
+	var keys []int
+	for k, v := range itr.Seq2() {
+		keys = append(keys, k)
+		assert.Equal(t, slices.Range(k*4, min(k*4+4, 10)), v)
+	}
+	assert.Equal(t, []int{0, 1, 2}, keys)
+}
+
+func TestChunkByInfinite(t *testing.T) {
+	itr := iterator.ChunkBy(fibSeq(), func(n int) bool { return n%2 == 0 })
+	assert.True(t, itr.Size().IsUnknown())
+	assert.Equal(t, [][]int{{1, 1}, {2}, {3, 5}, {8}}, iterator.Take(4, itr).Collect())
+}
+
+func TestMergeSorted(t *testing.T) {
+	itr := iterator.MergeSorted(cmp.Compare, iterator.Of(1, 4, 7), iterator.Of(2, 3, 8), iterator.Empty[int](), iterator.Of(0, 9))
+	assert.True(t, itr.Size().IsKnownToBe(8))
+	require.True(t, itr.Next())
+	assert.Equal(t, 0, itr.Value())
+	assert.True(t, itr.Size().IsKnownToBe(7))
+	assert.Equal(t, []int{1, 2, 3, 4, 7, 8, 9}, itr.Collect())
+	assert.True(t, itr.Size().IsKnownToBe(0))
+	itr.Reset()
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 7, 8, 9}, itr.Collect())
+}
+
+func TestMergeSortedStable(t *testing.T) {
+	type item struct {
+		key    int
+		source string
+	}
+	byKey := func(a, b item) int { return cmp.Compare(a.key, b.key) }
+	itr := iterator.MergeSorted(byKey,
+		iterator.Of(item{1, "a"}, item{2, "a"}),
+		iterator.Of(item{1, "b"}, item{2, "b"}))
+	assert.Equal(t, []item{{1, "a"}, {1, "b"}, {2, "a"}, {2, "b"}}, itr.Collect())
+}
+
+func TestMergeSortedSizes(t *testing.T) {
+	filtered := iterator.Range(0, 5).Filter(func(int) bool { return true })
+	assert.True(t, iterator.MergeSorted(cmp.Compare, iterator.Range(0, 3), filtered).Size().IsMaxKnownToBe(8))
+	assert.True(t, iterator.MergeSorted(cmp.Compare, iterator.Range(0, 3), fibSeq()).Size().IsInfinite())
+	assert.Equal(t, []int{0, 1, 1, 1, 2, 2, 3}, iterator.MergeSorted(cmp.Compare, iterator.Range(0, 3), fibSeq()).Take(7).Collect())
+}
+
+func TestMergeSortedAbort(t *testing.T) {
+	inputs := []iterator.Iterator[int]{iterator.Range(0, 10), iterator.Range(5, 15)}
+	itr := iterator.MergeSorted(cmp.Compare, inputs[0], inputs[1])
+	require.True(t, itr.Next())
+	itr.Abort()
+	assert.False(t, itr.Next())
+	for _, input := range inputs {
+		assert.True(t, input.Size().IsKnownToBe(0))
+	}
+}
+
+func TestMergeJoin(t *testing.T) {
+	left := iterator.Zip(iterator.Of(1, 2, 4, 4, 6), iterator.Of("a", "b", "c", "d", "e"))
+	right := iterator.Zip(iterator.Of(0, 2, 3, 4, 4, 7), iterator.Of(0.0, 2.0, 3.0, 4.0, 4.5, 7.0))
+	itr := iterator.MergeJoin(cmp.Compare, left, right)
+	assert.False(t, itr.Size().IsKnown())
+	assert.Equal(t, []iterator.KeyValue[int, tuple.Tuple2[string, float64]]{
+		{2, tuple.Pair("b", 2.0)},
+		{4, tuple.Pair("c", 4.0)},
+		{4, tuple.Pair("c", 4.5)},
+		{4, tuple.Pair("d", 4.0)},
+		{4, tuple.Pair("d", 4.5)},
+	}, itr.Collect2())
+	assert.True(t, itr.Size().IsKnownToBe(0))
+}
+
+func TestMergeJoinDuplicates(t *testing.T) {
+	left := iterator.Zip(iterator.Of(1, 1, 2, 3, 3, 3), iterator.Of("a", "b", "c", "d", "e", "f"))
+	right := iterator.Zip(iterator.Of(1, 1, 1, 3, 3), iterator.Range(0, 5))
+	itr := iterator.MergeJoin(cmp.Compare, left, right)
+	var actual []string
+	for k, v := range itr.Seq2() {
+		actual = append(actual, fmt.Sprintf("%d%s%d", k, v.First, v.Second))
+	}
+	assert.Equal(t, []string{
+		"1a0", "1a1", "1a2", "1b0", "1b1", "1b2",
+		"3d3", "3d4", "3e3", "3e4", "3f3", "3f4",
+	}, actual)
+	itr.Reset()
+	assert.Equal(t, 12, len(itr.Collect()))
+	// Intersect produces each pair of left once, however often its key repeats in right
+	left.Reset()
+	right.Reset()
+	assert.Equal(t, []string{"a", "b", "d", "e", "f"}, iterator.Intersect(cmp.Compare, left, right).Collect())
+}
+
+func TestIntersect(t *testing.T) {
+	left := iterator.Range(0, 10).Enumerate()
+	right := iterator.Zip(iterator.Of(1, 3, 5), iterator.Of("x", "y", "z"))
+	itr := iterator.Intersect(cmp.Compare, left, right)
+	actual := make(map[int]int)
+	for k, v := range itr.Seq2() {
+		actual[k] = v
+	}
+	assert.Equal(t, map[int]int{1: 1, 3: 3, 5: 5}, actual)
+	assert.True(t, left.Size().IsKnownToBe(0)) // Left aborted once right is exhausted
+}
+
+func TestDifference(t *testing.T) {
+	left := iterator.Zip(iterator.Of("a", "b", "c", "d"), iterator.Range(0, 4))
+	right := iterator.Zip(iterator.Of("b", "d", "e"), iterator.Range(0, 3))
+	itr := iterator.Difference(cmp.Compare, left, right)
+	assert.True(t, itr.Size().IsMaxKnownToBe(4))
+	assert.Equal(t, []iterator.KeyValue[string, int]{{"a", 0}, {"c", 2}}, itr.Collect2())
+	itr.Reset()
+	assert.Equal(t, []int{0, 2}, itr.Collect())
+}
+
+func TestMergeJoinAbort(t *testing.T) {
+	left, right := iterator.Range(0, 10).Enumerate(), iterator.Range(0, 10).Enumerate()
+	itr := iterator.Intersect(cmp.Compare, left, right)
+	require.True(t, itr.Next())
+	itr.Abort()
+	assert.False(t, itr.Next())
+	assert.True(t, left.Size().IsKnownToBe(0))
+	assert.True(t, right.Size().IsKnownToBe(0))
+}
+
+func TestDistinct(t *testing.T) {
+	itr := iterator.Distinct(iterator.Of(3, 1, 3, 2, 1, 4))
+	assert.True(t, itr.Size().IsMaxKnownToBe(6))
+	assert.Equal(t, []int{3, 1, 2, 4}, itr.Collect())
+	itr.Reset()
+	assert.Equal(t, []int{3, 1, 2, 4}, itr.Collect())
+}
+
+func TestDistinctSeq(t *testing.T) {
+	itr := iterator.Distinct(iterator.New(rangeSeq(0, 20, 1)).Morph(func(n int) int { return n % 3 }))
+	var actual []int
+	for v := range itr.Seq() {
+		actual = append(actual, v)
+	}
+	assert.Equal(t, []int{0, 1, 2}, actual)
+}
+
+func TestDistinctBy(t *testing.T) {
+	words := iterator.Of("pear", "apple", "plum", "avocado", "banana", "peach")
+	itr := iterator.DistinctBy(words, func(w string) byte { return w[0] })
+	assert.Equal(t, []string{"pear", "apple", "banana"}, itr.Collect())
+}
+
+func TestDedup(t *testing.T) {
+	itr := iterator.Dedup(iterator.Of(1, 1, 2, 2, 2, 1, 3, 3))
+	assert.True(t, itr.Size().IsMaxKnownToBe(8))
+	assert.Equal(t, []int{1, 2, 1, 3}, itr.Collect())
+	itr.Reset()
+	assert.Equal(t, []int{1, 2, 1, 3}, itr.Collect())
+	assert.True(t, iterator.Dedup(fibSeq()).Size().IsInfinite())
+	assert.Equal(t, []int{1, 2, 3, 5}, iterator.Dedup(fibSeq()).Take(4).Collect())
+}
+
+func TestDistinct2(t *testing.T) {
+	input := map[string]int{"a": 1, "b": 2, "c": 1, "d": 2, "e": 3}
+	itr := iterator.Distinct2(maps.Iter(input))
+	assert.True(t, itr.Size().IsMaxKnownToBe(5))
+	output := iterator.CollectMap(itr)
+	assert.Len(t, output, 3)
+	assert.ElementsMatch(t, []int{1, 2, 3}, maps.Values(output))
+}
+
+func TestDistinctBy2(t *testing.T) {
+	itr := iterator.Of(5, 6, 7, 8, 9).Enumerate()
+	distinct := iterator.DistinctBy2(itr, func(k, v int) int { return (k + v) % 4 })
+	assert.Equal(t, []iterator.KeyValue[int, int]{{0, 5}, {1, 6}}, distinct.Collect2())
+	seqItr := iterator.DistinctBy2(testSeqIter2(0, 6, func(n int) int { return n / 2 }), func(_, v int) int { return v })
+	actual := make(map[int]int)
+	for k, v := range seqItr.Seq2() {
+		actual[k] = v
+	}
+	assert.Equal(t, map[int]int{0: 0, 2: 1, 4: 2}, actual)
+}
+
+func TestDedup2(t *testing.T) {
+	itr := iterator.Dedup2(iterator.Of("a", "a", "b", "a").Enumerate())
+	assert.True(t, itr.Size().IsMaxKnownToBe(4))
+	assert.Equal(t, []iterator.KeyValue[int, string]{{0, "a"}, {2, "b"}, {3, "a"}}, itr.Collect2())
+	itr.Reset()
+	assert.Equal(t, []string{"a", "b", "a"}, itr.Collect())
+}
+
+func TestTryMap(t *testing.T) {
+	base := iterator.Of("1", "2", "x", "4")
+	itr := iterator.TryMap(base, strconv.Atoi)
+	assert.True(t, itr.Size().IsMaxKnownToBe(4))
+	assert.Equal(t, []int{1, 2}, itr.Collect())
+	var numErr *strconv.NumError
+	assert.ErrorAs(t, itr.Err(), &numErr)
+	assert.True(t, itr.Size().IsKnownToBe(0))
+	assert.True(t, base.Size().IsKnownToBe(0)) // base is aborted
+	itr.Reset()
+	assert.True(t, itr.Next())
+	assert.NoError(t, itr.Err())
+}
+
+func TestTryMapSuccess(t *testing.T) {
+	itr := iterator.TryMap(iterator.Of("1", "2", "3"), strconv.Atoi)
+	var actual []int
+	for v := range itr.Seq() {
+		actual = append(actual, v)
+	}
+	assert.Equal(t, []int{1, 2, 3}, actual)
+	assert.NoError(t, itr.Err())
+}
+
+func TestTryMapChain(t *testing.T) {
+	errOdd := errors.New("odd")
+	half := func(n int) (int, error) {
+		if n%2 != 0 {
+			return 0, errOdd
+		}
+		return n / 2, nil
+	}
+	itr := iterator.TryMap(iterator.TryMap(iterator.Of("8", "4", "6", "3"), strconv.Atoi), half)
+	assert.Equal(t, []int{4, 2, 3}, itr.Collect())
+	assert.ErrorIs(t, itr.Err(), errOdd)
+	itr = iterator.TryMap(iterator.TryMap(iterator.Of("8", "y", "6"), strconv.Atoi), half)
+	assert.Equal(t, []int{4}, itr.Collect())
+	assert.ErrorContains(t, itr.Err(), `parsing "y"`)
+}
+
+func TestMapErr(t *testing.T) {
+	itr := iterator.MapErr(iterator.TryMap(iterator.Of("1", "x"), strconv.Atoi), func(err error) error {
+		return fmt.Errorf("line 2: %w", err)
+	})
+	assert.Equal(t, []int{1}, itr.Collect())
+	assert.ErrorContains(t, itr.Err(), "line 2: strconv.Atoi")
+	ok := iterator.MapErr(iterator.TryMap(iterator.Of("1"), strconv.Atoi), func(err error) error { panic("not called") })
+	assert.Equal(t, []int{1}, ok.Collect())
+	assert.NoError(t, ok.Err())
+}
+
+func TestFromToResults(t *testing.T) {
+	errBad := errors.New("bad")
+	results := iterator.Of(result.Value(1), result.Value(2), result.Error[int](errBad), result.Value(3))
+	itr := iterator.FromResults(results)
+	assert.Equal(t, []int{1, 2}, itr.Collect())
+	assert.ErrorIs(t, itr.Err(), errBad)
+	itr.Reset()
+	back := iterator.ToResults(itr)
+	assert.True(t, back.Size().IsMaxKnownToBe(5))
+	collected := back.Collect()
+	require.Len(t, collected, 3)
+	assert.Equal(t, 2, collected[1].Get())
+	assert.ErrorIs(t, collected[2].GetErr(), errBad)
+	assert.True(t, back.Size().IsKnownToBe(0))
+}
+
+func TestSeqErr(t *testing.T) {
+	errBad := errors.New("bad")
+	seq := func(yield func(int, error) bool) {
+		for n := range 5 {
+			if n == 3 {
+				yield(0, errBad)
+				return
+			}
+			if !yield(n, nil) {
+				return
+			}
+		}
+	}
+	itr := iterator.NewErr(seq)
+	var values []int
+	var errs []error
+	for v, err := range iterator.SeqErr(itr) {
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			values = append(values, v)
+		}
+	}
+	assert.Equal(t, []int{0, 1, 2}, values)
+	assert.Equal(t, []error{errBad}, errs)
+}
+
+// checkNoGoroutineLeak waits for the number of goroutines to return to the
+// given number, failing the test if this doesn't happen within a second.
+func checkNoGoroutineLeak(t *testing.T, before int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before {
+		if time.Now().After(deadline) {
+			buf := make([]byte, 1<<16)
+			t.Fatalf("goroutine leak: %d before, %d after\n%s", before, runtime.NumGoroutine(), buf[:runtime.Stack(buf, true)])
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func countGen(c iterator.Consumer[int]) {
+	for n := 0; ; n++ {
+		c.Yield(n)
+	}
+}
+
+func TestGenerateCtx(t *testing.T) {
+	before := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	itr := iterator.GenerateCtx(ctx, countGen)
+	assert.Equal(t, []int{0, 1, 2}, itr.Take(3).Collect())
+	cancel()
+	assert.False(t, itr.Next())
+	assert.ErrorIs(t, itr.Err(), context.Canceled)
+	assert.True(t, itr.Size().IsKnownToBe(0))
+	checkNoGoroutineLeak(t, before)
+}
+
+func TestGenerateCtxComplete(t *testing.T) {
+	before := runtime.NumGoroutine()
+	itr := iterator.GenerateCtx(context.Background(), func(c iterator.Consumer[int]) {
+		for n := range 3 {
+			c.Yield(n)
+		}
+	})
+	assert.Equal(t, []int{0, 1, 2}, itr.Collect())
+	assert.NoError(t, itr.Err())
+	checkNoGoroutineLeak(t, before)
+}
+
+func TestGenerateCtxAbort(t *testing.T) {
+	before := runtime.NumGoroutine()
+	itr := iterator.GenerateCtx(context.Background(), countGen)
+	assert.True(t, itr.Next())
+	itr.Abort()
+	assert.False(t, itr.Next())
+	assert.NoError(t, itr.Err())
+	checkNoGoroutineLeak(t, before)
+}
+
+func TestGenerateCtxUnread(t *testing.T) {
+	// The generator stops once the context is cancelled, even if the
+	// iterator is never read again.
+	before := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+	itr := iterator.GenerateCtx(ctx, countGen)
+	assert.True(t, itr.Next())
+	cancel()
+	checkNoGoroutineLeak(t, before)
+}
+
+func TestGenerateCtxDeadline(t *testing.T) {
+	before := runtime.NumGoroutine()
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	itr := iterator.GenerateCtx(ctx, func(c iterator.Consumer[int]) {
+		c.Yield(1)
+		<-c.Context().Done() // A slow producer
+	})
+	assert.Equal(t, []int{1}, itr.Collect())
+	assert.ErrorIs(t, itr.Err(), context.DeadlineExceeded)
+	checkNoGoroutineLeak(t, before)
+}
+
+func TestGenerateResultsCtx(t *testing.T) {
+	before := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	itr := iterator.GenerateResultsCtx(ctx, func(c iterator.ResultConsumer[int]) error {
+		for n := 0; ; n++ {
+			if n == 2 {
+				cancel()
+				<-c.Context().Done()
+				return c.Context().Err()
+			}
+			c.YieldValue(n)
+		}
+	})
+	assert.Equal(t, []int{0, 1}, itr.Collect())
+	assert.ErrorIs(t, itr.Err(), context.Canceled)
+	assert.False(t, itr.Next())
+	checkNoGoroutineLeak(t, before)
+}
+
+func TestGenerateResultsCtxError(t *testing.T) {
+	before := runtime.NumGoroutine()
+	errBad := errors.New("bad")
+	itr := iterator.GenerateResultsCtx(context.Background(), func(c iterator.ResultConsumer[int]) error {
+		c.YieldValue(1)
+		c.YieldError(errBad)
+		c.YieldValue(2)
+		return nil
+	})
+	assert.Equal(t, []int{1}, itr.Collect())
+	assert.Equal(t, errBad, itr.Err())
+	checkNoGoroutineLeak(t, before)
+}
+
+func countTo(n int) iterator.Generator[int] {
+	return func(c iterator.Consumer[int]) {
+		for i := range n {
+			c.Yield(i)
+		}
+	}
+}
+
+func TestGenerateOptions(t *testing.T) {
+	options := map[string][]iterator.GenOption{
+		"default":  nil,
+		"buffer":   {iterator.GenBuffer(4)},
+		"prefetch": {iterator.GenPrefetch(10), iterator.GenBatch(3)},
+		"batch":    {iterator.GenBatch(3)},
+		"batch1":   {iterator.GenBatch(1)},
+		"both":     {iterator.GenBatch(4), iterator.GenBuffer(2)},
+	}
+	for name, opts := range options {
+		t.Run(name, func(t *testing.T) {
+			for _, n := range []int{0, 1, 3, 10} {
+				assert.Equal(t, slices.Range(0, n), iterator.Generate(countTo(n), opts...).Collect())
+			}
+		})
+	}
+}
+
+func TestGenerateBufferRunsAhead(t *testing.T) {
+	produced := make(chan int, 10)
+	gen := iterator.Generate(func(c iterator.Consumer[int]) {
+		for i := range 10 {
+			c.Yield(i)
+			produced <- i
+		}
+	}, iterator.GenBuffer(3))
+	// Without any consumption, the generator can fill the buffer.
+	for i := range 3 {
+		assert.Equal(t, i, <-produced)
+	}
+	assert.Equal(t, slices.Range(0, 10), gen.Collect())
+}
+
+func TestGenerateBatchAbort(t *testing.T) {
+	before := runtime.NumGoroutine()
+	gen := iterator.Generate(countGen, iterator.GenBatch(8), iterator.GenBuffer(2))
+	assert.Equal(t, slices.Range(0, 20), gen.Take(20).Collect())
+	gen.Abort()
+	assert.False(t, gen.Next())
+	checkNoGoroutineLeak(t, before)
+}
+
+func TestGenerateResultsBatch(t *testing.T) {
+	gen := iterator.GenerateResults(func(c iterator.ResultConsumer[int]) error {
+		for i := range 10 {
+			c.YieldValue(i)
+		}
+		return fmt.Errorf("iterator failed")
+	}, iterator.GenBatch(4))
+	actual, err := iterator.CollectResults(gen)
+	assert.Equal(t, slices.Range(0, 10), actual)
+	assert.EqualError(t, err, "iterator failed")
+}
+
+func TestGenerateResultsBatchPanic(t *testing.T) {
+	gen := iterator.GenerateResults(func(c iterator.ResultConsumer[int]) error {
+		c.YieldValue(1)
+		panic("oops")
+	}, iterator.GenBatch(4))
+	actual, err := iterator.CollectResults(gen)
+	assert.Equal(t, []int{1}, actual)
+	var genPanic iterator.GeneratorPanic
+	assert.ErrorAs(t, err, &genPanic)
+}
+
+func benchmarkGenerate(b *testing.B, opts ...iterator.GenOption) {
+	var sum int
+	for v := range iterator.Generate(countTo(b.N), opts...).Seq() {
+		sum += v
+	}
+	assert.Equal(b, b.N*(b.N-1)/2, sum)
+}
+
+func BenchmarkGenerateUnbuffered(b *testing.B) {
+	benchmarkGenerate(b)
+}
+
+func BenchmarkGenerateBuffered(b *testing.B) {
+	benchmarkGenerate(b, iterator.GenBuffer(64))
+}
+
+func BenchmarkGenerateBatched(b *testing.B) {
+	benchmarkGenerate(b, iterator.GenBatch(64))
+}
+
+func BenchmarkGenerateBatchedPrefetch(b *testing.B) {
+	benchmarkGenerate(b, iterator.GenBatch(64), iterator.GenPrefetch(256))
+}
+
+func TestPeekable(t *testing.T) {
+	itr := iterator.NewPeekable(iterator.Range(0, 5))
+	assert.True(t, itr.Size().IsKnownToBe(5))
+	v, ok := itr.Peek()
+	assert.True(t, ok)
+	assert.Equal(t, 0, v)
+	assert.True(t, itr.Size().IsKnownToBe(5))
+	assert.Equal(t, []int{0, 1, 2}, itr.PeekN(3))
+	assert.True(t, itr.Size().IsKnownToBe(5))
+	require.True(t, itr.Next())
+	assert.Equal(t, 0, itr.Value())
+	itr.PushBack(10)
+	itr.PushBack(11)
+	assert.True(t, itr.Size().IsKnownToBe(6))
+	assert.Equal(t, []int{11, 10, 1, 2, 3, 4}, itr.Collect())
+	_, ok = itr.Peek()
+	assert.False(t, ok)
+	assert.Empty(t, itr.PeekN(2))
+}
+
+func TestPeekableNextIf(t *testing.T) {
+	isDigit := func(r rune) bool { return r >= '0' && r <= '9' }
+	itr := iterator.NewPeekable(slices.Iter([]rune("12+3")))
+	var digits []rune
+	for {
+		r, ok := itr.NextIf(isDigit)
+		if !ok {
+			break
+		}
+		digits = append(digits, r)
+	}
+	assert.Equal(t, "12", string(digits))
+	op, ok := itr.Peek()
+	assert.True(t, ok)
+	assert.Equal(t, '+', op)
+	assert.Equal(t, "+3", string(itr.Collect()))
+}
+
+func TestPeekableSeq(t *testing.T) {
+	itr := iterator.NewPeekable(iterator.New(rangeSeq(0, 6, 1)))
+	var actual []int
+	for v := range itr.Seq() {
+		actual = append(actual, v)
+		if v == 2 {
+			itr.PushBack(20)
+		}
+		if next, ok := itr.Peek(); ok && next == 4 {
+			break
+		}
+	}
+	assert.Equal(t, []int{0, 1, 2, 20, 3}, actual)
+	assert.Equal(t, []int{4, 5}, itr.Collect())
+}
+
+func TestPeekableInfinite(t *testing.T) {
+	itr := iterator.NewPeekable(fibSeq())
+	assert.Equal(t, []int{1, 1, 2, 3}, itr.PeekN(4))
+	assert.True(t, itr.Size().IsInfinite())
+	assert.Equal(t, []int{1, 1, 2, 3, 5}, itr.Take(5).Collect())
+}
+
+func TestPeekableAbortReset(t *testing.T) {
+	itr := iterator.NewPeekable(iterator.Range(0, 5))
+	itr.PeekN(2)
+	itr.Abort()
+	assert.False(t, itr.Next())
+	itr.Reset()
+	itr.PushBack(-1)
+	assert.Equal(t, []int{-1, 0, 1, 2, 3, 4}, itr.Collect())
+}
+
+func TestScan(t *testing.T) {
+	itr := iterator.Scan(iterator.Of(1, 2, 3), 10, functions.Sum)
+	assert.True(t, itr.Size().IsKnownToBe(4))
+	require.True(t, itr.Next())
+	assert.Equal(t, 10, itr.Value())
+	assert.True(t, itr.Size().IsKnownToBe(3))
+	assert.Equal(t, []int{11, 13, 16}, itr.Collect())
+	itr.Reset()
+	assert.Equal(t, []int{10, 11, 13, 16}, itr.Collect())
+}
+
+func TestScanEmpty(t *testing.T) {
+	itr := iterator.Scan(iterator.Empty[int](), "", func(a string, e int) string { return a + strconv.Itoa(e) })
+	assert.True(t, itr.Size().IsKnownToBe(1))
+	assert.Equal(t, []string{""}, itr.Collect())
+}
+
+func TestScanSeq(t *testing.T) {
+	itr := iterator.Scan(iterator.New(rangeSeq(1, 5, 1)), "", func(a string, e int) string { return a + strconv.Itoa(e) })
+	assert.True(t, itr.SeqOK())
+	assert.True(t, itr.Size().IsUnknown())
+	var actual []string
+	for v := range itr.Seq() {
+		actual = append(actual, v)
+	}
+	assert.Equal(t, []string{"", "1", "12", "123", "1234"}, actual)
+}
+
+func TestScanSizes(t *testing.T) {
+	filtered := iterator.Range(0, 5).Filter(func(int) bool { return true })
+	assert.True(t, iterator.Scan(filtered, 0, functions.Sum).Size().IsMaxKnownToBe(6))
+	assert.True(t, iterator.Scan(fibSeq(), 0, functions.Sum).Size().IsInfinite())
+	assert.Equal(t, []int{0, 1, 2, 4, 7}, iterator.Scan(fibSeq(), 0, functions.Sum).Take(5).Collect())
+}
+
+func TestScan1(t *testing.T) {
+	itr := iterator.Scan1(iterator.Of(1, 2, 3), functions.Sum)
+	assert.True(t, itr.Size().IsKnownToBe(3))
+	assert.Equal(t, []int{1, 3, 6}, itr.Collect())
+	itr.Reset()
+	var actual []int
+	for v := range itr.Seq() {
+		actual = append(actual, v)
+	}
+	assert.Equal(t, []int{1, 3, 6}, actual)
+	assert.Empty(t, iterator.Scan1(iterator.Empty[int](), functions.Sum).Collect())
+}
+
+func TestPrefixSum(t *testing.T) {
+	assert.Equal(t, []int{1, 3, 6, 10}, iterator.PrefixSum(iterator.IncRange(1, 4)).Collect())
+	assert.Equal(t, []float64{0.5, 1.0, 2.5}, iterator.PrefixSum(iterator.Of(0.5, 0.5, 1.5)).Collect())
+}
+
+func TestPrefixProduct(t *testing.T) {
+	itr := iterator.PrefixProduct(iterator.IncRange(1, 5))
+	assert.True(t, itr.Size().IsKnownToBe(5))
+	assert.Equal(t, []int{1, 2, 6, 24, 120}, itr.Collect())
+}
+
+func TestMinMax(t *testing.T) {
+	assert.Equal(t, opt.Value(1), iterator.Min(iterator.Of(3, 1, 4, 1, 5)))
+	assert.Equal(t, opt.Value(5), iterator.Max(iterator.Of(3, 1, 4, 1, 5)))
+	assert.Equal(t, opt.Value("apple"), iterator.Min(iterator.Of("pear", "apple", "plum")))
+	assert.True(t, iterator.Min(iterator.Empty[int]()).IsEmpty())
+	assert.True(t, iterator.Max(iterator.Empty[int]()).IsEmpty())
+	assert.PanicsWithError(t, iterator.ErrSizeInfinite.Error(), func() { iterator.Max(fibSeq()) })
+}
+
+func TestMinMaxBy(t *testing.T) {
+	byLen := func(a, b string) int { return cmp.Compare(len(a), len(b)) }
+	words := []string{"pear", "fig", "plum", "banana", "kiwi", "cherry"}
+	assert.Equal(t, opt.Value("fig"), iterator.MinBy(slices.Iter(words), byLen))
+	assert.Equal(t, opt.Value("banana"), iterator.MaxBy(slices.Iter(words), byLen))
+	assert.True(t, iterator.MinBy(iterator.Empty[string](), byLen).IsEmpty())
+}
+
+func TestSumProductCount(t *testing.T) {
+	assert.Equal(t, 15, iterator.Sum(iterator.IncRange(1, 5)))
+	assert.Equal(t, 120, iterator.Product(iterator.IncRange(1, 5)))
+	assert.Equal(t, 0, iterator.Sum(iterator.Empty[int]()))
+	assert.Equal(t, 1.0, iterator.Product(iterator.Empty[float64]()))
+	assert.Equal(t, 2.5, iterator.Sum(iterator.Of(1.0, 1.5)))
+	assert.Equal(t, 4, iterator.Count(iterator.New(rangeSeq(0, 8, 2))))
+	assert.Equal(t, 0, iterator.Count(iterator.Empty[string]()))
+}
+
+func TestAverage(t *testing.T) {
+	assert.Equal(t, opt.Value(2.5), iterator.Average(iterator.Of(1, 2, 3, 4)))
+	assert.True(t, iterator.Average(iterator.Empty[float32]()).IsEmpty())
+}
+
+func TestStats(t *testing.T) {
+	stats, ok := iterator.Stats(iterator.Of(2, 4, 4, 4, 5, 5, 7, 9)).GetOK()
+	require.True(t, ok)
+	assert.Equal(t, 8, stats.Count)
+	assert.Equal(t, 2, stats.Min)
+	assert.Equal(t, 9, stats.Max)
+	assert.Equal(t, 40.0, stats.Sum)
+	assert.Equal(t, 5.0, stats.Mean)
+	assert.InDelta(t, 4.0, stats.Variance, 1e-9)
+	assert.InDelta(t, 2.0, stats.StdDev(), 1e-9)
+	assert.True(t, iterator.Stats(iterator.Empty[int]()).IsEmpty())
+	single := iterator.Stats(iterator.Of(-1.5)).Get()
+	assert.Equal(t, iterator.Statistics[float64]{Count: 1, Min: -1.5, Max: -1.5, Sum: -1.5, Mean: -1.5}, single)
+}
+
+func TestRepeat(t *testing.T) {
+	itr := iterator.Repeat("a")
+	assert.True(t, itr.Size().IsInfinite())
+	assert.PanicsWithError(t, iterator.ErrSizeInfinite.Error(), func() { itr.Collect() })
+	taken := itr.Take(3)
+	assert.True(t, taken.Size().IsKnownToBe(3))
+	assert.Equal(t, []string{"a", "a", "a"}, taken.Collect())
+	var count int
+	for range iterator.Repeat(1).Seq() {
+		if count++; count == 5 {
+			break
+		}
+	}
+	assert.Equal(t, 5, count)
+}
+
+func TestRepeatN(t *testing.T) {
+	itr := iterator.RepeatN(7, 3)
+	assert.True(t, itr.Size().IsKnownToBe(3))
+	assert.True(t, itr.Next())
+	assert.True(t, itr.Size().IsKnownToBe(2))
+	assert.Equal(t, []int{7, 7}, itr.Collect())
+	itr.Reset()
+	assert.Equal(t, []int{7, 7, 7}, itr.Collect())
+	assert.Empty(t, iterator.RepeatN(7, 0).Collect())
+	assert.Panics(t, func() { iterator.RepeatN(7, -1) })
+}
+
+func TestCycle(t *testing.T) {
+	var reads int
+	base := iterator.Map(iterator.Of(1, 2, 3), func(v int) int { reads++; return v })
+	itr := iterator.Cycle(base)
+	assert.True(t, itr.Size().IsInfinite())
+	assert.PanicsWithError(t, iterator.ErrSizeInfinite.Error(), func() { itr.Collect() })
+	assert.Equal(t, []int{1, 2, 3, 1, 2, 3, 1}, itr.Take(7).Collect())
+	assert.Equal(t, 3, reads)
+}
+
+func TestCycleEmpty(t *testing.T) {
+	assert.True(t, iterator.Cycle(iterator.Empty[int]()).Size().IsKnownToBe(0))
+	assert.Empty(t, iterator.Cycle(iterator.Empty[int]()).Collect())
+	unknown := iterator.Cycle(iterator.New(iterator.EmptySeq[int]()))
+	assert.True(t, unknown.Size().IsUnknown())
+	assert.Empty(t, unknown.Collect())
+}
+
+func TestCycleUnknownSize(t *testing.T) {
+	itr := iterator.Cycle(iterator.New(rangeSeq(0, 2, 1)))
+	assert.True(t, itr.Size().IsUnknown())
+	assert.True(t, itr.Next())
+	assert.True(t, itr.Size().IsInfinite())
+	var result []int
+	for v := range itr.Seq() {
+		if result = append(result, v); len(result) == 4 {
+			break
+		}
+	}
+	assert.Equal(t, []int{1, 0, 1, 0}, result)
+	assert.False(t, itr.Next())
+}
+
+func TestIterate(t *testing.T) {
+	itr := iterator.Iterate(1, func(n int) int { return n * 2 })
+	assert.True(t, itr.Size().IsInfinite())
+	assert.PanicsWithError(t, iterator.ErrSizeInfinite.Error(), func() { itr.Collect() })
+	assert.Equal(t, []int{1, 2, 4, 8, 16}, itr.Take(5).Collect())
+	itr.Reset()
+	assert.Equal(t, []int{1, 2}, itr.Take(2).Collect())
+}
+
+func TestUnfold(t *testing.T) {
+	digits := iterator.Unfold(1234, func(n int) (int, int, bool) { return n % 10, n / 10, n > 0 })
+	assert.True(t, digits.Size().IsUnknown())
+	assert.Equal(t, []int{4, 3, 2, 1}, digits.Collect())
+	assert.True(t, digits.Size().IsKnownToBe(0))
+	digits.Reset()
+	assert.Equal(t, []int{4, 3}, digits.Take(2).Collect())
+	fib := iterator.Unfold([2]int{0, 1}, func(s [2]int) (int, [2]int, bool) { return s[1], [2]int{s[1], s[0] + s[1]}, true })
+	assert.Equal(t, []int{1, 1, 2, 3, 5}, fib.Take(5).Collect())
+}
+
+func TestProduct2(t *testing.T) {
+	itr := iterator.Product2(iterator.Of("x", "y"), iterator.Of(1, 2, 3))
+	assert.True(t, itr.Size().IsKnownToBe(6))
+	var keys []string
+	var values []int
+	for k, v := range itr.Seq2() {
+		keys = append(keys, k)
+		values = append(values, v)
+		assert.True(t, itr.Size().IsKnownToBe(6-len(keys)))
+	}
+	assert.Equal(t, []string{"x", "x", "x", "y", "y", "y"}, keys)
+	assert.Equal(t, []int{1, 2, 3, 1, 2, 3}, values)
+	itr.Reset()
+	assert.Equal(t, []int{1, 2, 3, 1, 2, 3}, itr.Collect())
+}
+
+func TestProduct2Sizes(t *testing.T) {
+	assert.True(t, iterator.Product2(iterator.Of(1, 2), iterator.Empty[int]()).Size().IsKnownToBe(0))
+	assert.Empty(t, iterator.Product2(iterator.Of(1, 2), iterator.Empty[int]()).Collect2())
+	assert.Empty(t, iterator.Product2(iterator.Empty[int](), iterator.Of(1, 2)).Collect2())
+	assert.True(t, iterator.Product2(iterator.Of(1, 2), iterator.Repeat(0)).Size().IsInfinite())
+	assert.True(t, iterator.Product2(iterator.Of(1, 2), iterator.Of(1).Filter(func(int) bool { return true })).Size().IsMaxKnownToBe(2))
+	assert.True(t, iterator.Product2(iterator.New(rangeSeq(0, 3, 1)), iterator.Of(1, 2)).Size().IsUnknown())
+	huge := iterator.Product2(iterator.RepeatN(0, math.MaxInt/2), iterator.RepeatN(0, 3))
+	assert.True(t, huge.Size().IsUnknown())
+}
+
+func TestProductN(t *testing.T) {
+	itr := iterator.ProductN(iterator.Of(1, 2), iterator.Of(3), iterator.Of(4, 5))
+	assert.True(t, itr.Size().IsKnownToBe(4))
+	assert.Equal(t, [][]int{{1, 3, 4}, {1, 3, 5}, {2, 3, 4}, {2, 3, 5}}, itr.Collect())
+	itr.Reset()
+	assert.True(t, itr.Next())
+	assert.True(t, itr.Size().IsKnownToBe(3))
+	assert.Equal(t, [][]int{{}}, iterator.ProductN[int]().Collect())
+	assert.Empty(t, iterator.ProductN(iterator.Of(1), iterator.Empty[int]()).Collect())
+	assert.PanicsWithError(t, iterator.ErrSizeInfinite.Error(), func() { iterator.ProductN(iterator.Repeat(1)).Next() })
+}
+
+func TestPermutations(t *testing.T) {
+	itr := iterator.Permutations([]int{1, 2, 3}, 2)
+	assert.True(t, itr.Size().IsKnownToBe(6))
+	assert.Equal(t, [][]int{{1, 2}, {1, 3}, {2, 1}, {2, 3}, {3, 1}, {3, 2}}, itr.Collect())
+	all := iterator.Permutations([]string{"a", "b", "c"}, 3).Collect()
+	assert.Equal(t, [][]string{{"a", "b", "c"}, {"a", "c", "b"}, {"b", "a", "c"}, {"b", "c", "a"}, {"c", "a", "b"}, {"c", "b", "a"}}, all)
+	assert.Equal(t, [][]int{{}}, iterator.Permutations([]int{1, 2}, 0).Collect())
+	assert.True(t, iterator.Permutations([]int{1, 2}, 3).Size().IsKnownToBe(0))
+	assert.Empty(t, iterator.Permutations([]int{1, 2}, 3).Collect())
+	assert.True(t, iterator.Permutations(make([]int, 10), 10).Size().IsKnownToBe(3628800))
+	assert.Equal(t, 3628800, iterator.Count(iterator.Permutations(make([]int, 10), 10)))
+	assert.True(t, iterator.Permutations(make([]int, 30), 30).Size().IsUnknown())
+	assert.Panics(t, func() { iterator.Permutations([]int{1}, -1) })
+}
+
+func TestCombinations(t *testing.T) {
+	itr := iterator.Combinations([]int{1, 2, 3, 4}, 2)
+	assert.True(t, itr.Size().IsKnownToBe(6))
+	assert.Equal(t, [][]int{{1, 2}, {1, 3}, {1, 4}, {2, 3}, {2, 4}, {3, 4}}, itr.Collect())
+	assert.Equal(t, [][]int{{}}, iterator.Combinations([]int{1, 2}, 0).Collect())
+	assert.Equal(t, [][]int{{1, 2}}, iterator.Combinations([]int{1, 2}, 2).Collect())
+	assert.Empty(t, iterator.Combinations([]int{1, 2}, 3).Collect())
+	assert.True(t, iterator.Combinations(make([]int, 60), 30).Size().IsKnownToBe(118264581564861424))
+	assert.True(t, iterator.Combinations(make([]int, 100), 50).Size().IsUnknown())
+	assert.Panics(t, func() { iterator.Combinations([]int{1}, -1) })
+}
+
+func TestPowerSet(t *testing.T) {
+	itr := iterator.PowerSet([]int{1, 2, 3})
+	assert.True(t, itr.Size().IsKnownToBe(8))
+	assert.Equal(t, [][]int{{}, {1}, {2}, {3}, {1, 2}, {1, 3}, {2, 3}, {1, 2, 3}}, itr.Collect())
+	assert.Equal(t, [][]int{{}}, iterator.PowerSet([]int{}).Collect())
+	assert.True(t, iterator.PowerSet(make([]int, 62)).Size().IsKnownToBe(1<<62))
+	assert.True(t, iterator.PowerSet(make([]int, 64)).Size().IsUnknown())
+}
+
+func TestTee(t *testing.T) {
+	var reads int
+	base := iterator.Map(iterator.Of(1, 2, 3, 4), func(v int) int { reads++; return v })
+	itrs := iterator.Tee(base, 3)
+	require.Len(t, itrs, 3)
+	for _, itr := range itrs {
+		assert.True(t, itr.Size().IsKnownToBe(4))
+	}
+	assert.True(t, itrs[0].Next())
+	assert.True(t, itrs[0].Next())
+	assert.True(t, itrs[0].Size().IsKnownToBe(2))
+	assert.True(t, itrs[1].Size().IsKnownToBe(4))
+	assert.Equal(t, []int{1, 2, 3, 4}, itrs[1].Collect())
+	assert.Equal(t, []int{3, 4}, itrs[0].Collect())
+	assert.Equal(t, []int{1, 2, 3, 4}, itrs[2].Collect())
+	assert.Equal(t, 4, reads)
+}
+
+func TestTeeSeq(t *testing.T) {
+	itrs := iterator.Tee(iterator.New(rangeSeq(0, 5, 1)), 2)
+	var pairs [][2]int
+	for v := range itrs[0].Seq() {
+		require.True(t, itrs[1].Next())
+		pairs = append(pairs, [2]int{v, itrs[1].Value()})
+	}
+	assert.Equal(t, [][2]int{{0, 0}, {1, 1}, {2, 2}, {3, 3}, {4, 4}}, pairs)
+	assert.False(t, itrs[1].Next())
+}
+
+// abortTracker records whether the iterator it wraps has been aborted.
+type abortTracker[T any] struct {
+	iterator.CoreIterator[T]
+	aborted *bool
+}
+
+func (at *abortTracker[T]) Abort() {
+	*at.aborted = true
+	at.CoreIterator.Abort()
+}
+
+func TestTeeAbort(t *testing.T) {
+	var aborted bool
+	base := iterator.NewDefaultIterator(&abortTracker[int]{CoreIterator: iterator.Of(1, 2, 3), aborted: &aborted})
+	itrs := iterator.Tee(base, 2)
+	assert.True(t, itrs[0].Next())
+	itrs[0].Abort()
+	assert.False(t, aborted)
+	assert.True(t, itrs[0].Size().IsKnownToBe(0))
+	assert.False(t, itrs[0].Next())
+	assert.True(t, itrs[1].Next())
+	assert.True(t, itrs[1].Size().IsKnownToBe(2))
+	itrs[1].Abort()
+	assert.True(t, aborted)
+}
+
+func TestTeeReset(t *testing.T) {
+	itrs := iterator.Tee(iterator.Of(1, 2, 3), 2)
+	assert.Equal(t, []int{1, 2, 3}, itrs[0].Collect())
+	itrs[1].Reset()
+	assert.Equal(t, []int{1, 2, 3}, itrs[0].Collect())
+	assert.Equal(t, []int{1, 2, 3}, itrs[1].Collect())
+	assert.Empty(t, iterator.Tee(iterator.Of(1), 0))
+	assert.Panics(t, func() { iterator.Tee(iterator.Of(1), -1) })
+}
+
+func TestMemoize(t *testing.T) {
+	var reads int
+	seq := func(yield func(int) bool) {
+		for i := range 4 {
+			reads++
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	itr := iterator.Memoize(iterator.New(seq))
+	assert.Equal(t, []int{0, 1, 2, 3}, itr.Collect())
+	assert.True(t, itr.Size().IsKnownToBe(0))
+	itr.Reset()
+	assert.True(t, itr.Size().IsKnownToBe(4))
+	assert.Equal(t, []int{0, 1, 2, 3}, itr.Collect())
+	assert.Equal(t, 4, reads)
+}
+
+func TestMemoizePartial(t *testing.T) {
+	itr := iterator.Memoize(iterator.Of(1, 2, 3, 4))
+	assert.True(t, itr.Next())
+	assert.True(t, itr.Next())
+	itr.Reset()
+	assert.True(t, itr.Size().IsKnownToBe(4))
+	assert.Equal(t, []int{1, 2, 3, 4}, itr.Collect())
+	itr.Reset()
+	assert.Equal(t, []int{1, 2}, itr.Take(2).Collect())
+	itr.Reset()
+	assert.Equal(t, []int{1, 2, 3, 4}, itr.Collect())
+}
+
+func TestMemoizeBreak(t *testing.T) {
+	var reads int
+	seq := func(yield func(int) bool) {
+		for i := range 4 {
+			reads++
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	itr := iterator.Memoize(iterator.New(seq))
+	for v := range itr.Seq() {
+		if v == 1 {
+			break
+		}
+	}
+	assert.False(t, itr.Next())
+	itr.Reset()
+	assert.Equal(t, []int{0, 1, 2, 3}, itr.Collect())
+	itr.Reset()
+	assert.Equal(t, []int{0, 1, 2, 3}, itr.Collect())
+	assert.Equal(t, 4, reads)
+}
+
+func TestMemoizeAbort(t *testing.T) {
+	itr := iterator.Memoize(iterator.Of(1, 2, 3, 4))
+	assert.True(t, itr.Next())
+	assert.True(t, itr.Next())
+	itr.Abort()
+	assert.False(t, itr.Next())
+	itr.Reset()
+	assert.True(t, itr.Size().IsKnownToBe(2))
+	assert.Equal(t, []int{1, 2}, itr.Collect())
+}
+
+func TestSliceDoubleEnded(t *testing.T) {
+	itr, ok := iterator.Of(1, 2, 3, 4, 5).(iterator.DoubleEndedIterator[int])
+	require.True(t, ok)
+	assert.True(t, itr.NextBack())
+	assert.Equal(t, 5, itr.Value())
+	assert.True(t, itr.Next())
+	assert.Equal(t, 1, itr.Value())
+	assert.True(t, itr.Size().IsKnownToBe(3))
+	assert.True(t, itr.NextBack())
+	assert.Equal(t, 4, itr.Value())
+	assert.Equal(t, []int{2, 3}, itr.Collect())
+	assert.False(t, itr.NextBack())
+	itr.Reset()
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, itr.Collect())
+}
+
+func TestSliceDeleteBack(t *testing.T) {
+	s := []int{1, 2, 3, 4, 5}
+	itr := slices.IterMut(&s)
+	de, ok := itr.(iterator.DefaultMutableIterator[int]).CoreMutableIterator.(iterator.CoreDoubleEndedIterator[int])
+	require.True(t, ok)
+	assert.True(t, de.NextBack())
+	assert.True(t, de.NextBack())
+	assert.Equal(t, 4, itr.Value())
+	itr.Delete()
+	assert.Equal(t, []int{1, 2, 3, 5}, s)
+	assert.True(t, itr.Size().IsKnownToBe(3))
+	assert.Equal(t, []int{1, 2, 3}, itr.Collect())
+}
+
+func TestRangeDoubleEnded(t *testing.T) {
+	itr, ok := iterator.RangeBy(0, 10, 3).(iterator.DoubleEndedIterator[int])
+	require.True(t, ok)
+	assert.True(t, itr.NextBack())
+	assert.Equal(t, 9, itr.Value())
+	assert.True(t, itr.Size().IsKnownToBe(3))
+	assert.True(t, itr.Next())
+	assert.Equal(t, 0, itr.Value())
+	assert.True(t, itr.NextBack())
+	assert.Equal(t, 6, itr.Value())
+	assert.Equal(t, []int{3}, itr.Collect())
+	assert.False(t, itr.NextBack())
+	itr.Reset()
+	assert.Equal(t, []int{0, 3, 6, 9}, itr.Collect())
+}
+
+func TestRev(t *testing.T) {
+	assert.Equal(t, []int{4, 3, 2, 1, 0}, iterator.Rev(iterator.Range(0, 5)).Collect())
+	assert.Equal(t, []int{5, 4, 3, 2, 1}, iterator.Rev(iterator.IncRange(1, 5)).Collect())
+	assert.Equal(t, []int{1, 3, 5}, iterator.Rev(iterator.IncRangeBy(5, 1, -2)).Collect())
+	assert.Equal(t, []float64{1.5, 1, 0.5}, iterator.Rev(iterator.RangeBy(0.5, 2.0, 0.5)).Collect())
+	assert.Equal(t, []string{"c", "b", "a"}, iterator.Rev(iterator.Of("a", "b", "c")).Collect())
+	assert.Equal(t, []string{"c", "b", "a"}, iterator.Rev(slices.Iter([]string{"a", "b", "c"})).Collect())
+	assert.Equal(t, []int{2, 1}, iterator.Rev(iterator.Rev(iterator.Rev(iterator.Of(1, 2)))).Collect())
+	assert.Empty(t, iterator.Rev(iterator.Range(0, 0)).Collect())
+}
+
+func TestRevDoubleEnded(t *testing.T) {
+	itr := iterator.Rev(iterator.Of(1, 2, 3, 4))
+	assert.True(t, itr.NextBack())
+	assert.Equal(t, 1, itr.Value())
+	assert.Equal(t, []int{4, 3, 2}, itr.Collect())
+}
+
+func TestRevBuffered(t *testing.T) {
+	itr := iterator.Rev(iterator.New(rangeSeq(0, 5, 1)))
+	assert.True(t, itr.Size().IsUnknown())
+	assert.True(t, itr.Next())
+	assert.Equal(t, 4, itr.Value())
+	assert.True(t, itr.Size().IsKnownToBe(4))
+	assert.True(t, itr.NextBack())
+	assert.Equal(t, 0, itr.Value())
+	assert.Equal(t, []int{3, 2, 1}, itr.Collect())
+	assert.PanicsWithError(t, iterator.ErrSizeInfinite.Error(), func() { iterator.Rev(iterator.Repeat(1)).Next() })
+	aborted := iterator.Rev(iterator.Repeat(1))
+	aborted.Abort()
+	assert.False(t, aborted.Next())
+}
+
+func TestSliceRandomAccess(t *testing.T) {
+	itr := iterator.Of(1, 2, 3, 4, 5).(iterator.RandomAccessIterator[int])
+	assert.Equal(t, 5, itr.Len())
+	assert.Equal(t, 3, itr.At(2))
+	assert.True(t, itr.Next())
+	assert.True(t, itr.NextBack())
+	assert.Equal(t, 3, itr.Len())
+	assert.Equal(t, 2, itr.At(0))
+	assert.Equal(t, 4, itr.At(2))
+	assert.Equal(t, []int{3, 4}, itr.Slice(1, 3).Collect())
+	assert.Empty(t, itr.Slice(3, 3).Collect())
+	assert.Equal(t, 3, itr.Len())
+	assert.PanicsWithError(t, "invalid iterator range: index 3 out of range with length 3", func() { itr.At(3) })
+	assert.PanicsWithError(t, "invalid iterator range: slice bounds [2:1] out of range with length 3", func() { itr.Slice(2, 1) })
+}
+
+func TestRangeRandomAccess(t *testing.T) {
+	itr := iterator.RangeBy(10, 0, -3).(iterator.RandomAccessIterator[int])
+	assert.Equal(t, 4, itr.Len())
+	assert.Equal(t, []int{10, 7, 4, 1}, []int{itr.At(0), itr.At(1), itr.At(2), itr.At(3)})
+	sub := itr.Slice(1, 3)
+	assert.Equal(t, 2, sub.Len())
+	assert.Equal(t, []int{7, 4}, sub.Collect())
+	sub.Reset()
+	assert.True(t, sub.NextBack())
+	assert.Equal(t, 4, sub.Value())
+	assert.Equal(t, []int{0, 1, 2}, iterator.IncRange(0, 5).(iterator.RandomAccessIterator[int]).Slice(0, 3).Collect())
+	assert.Empty(t, iterator.IncRange(0, 5).(iterator.RandomAccessIterator[int]).Slice(6, 6).Collect())
+	assert.Equal(t, 4, itr.Len())
+}
+
+func TestSkipTakeStepByRandomAccess(t *testing.T) {
+	source := iterator.Range(0, 100)
+	itr, ok := source.Skip(10).StepBy(3).Take(5).(iterator.RandomAccessIterator[int])
+	require.True(t, ok)
+	assert.Equal(t, 5, itr.Len())
+	assert.Equal(t, 22, itr.At(4))
+	assert.Equal(t, []int{13, 16, 19}, itr.Slice(1, 4).Collect())
+	assert.True(t, itr.NextBack())
+	assert.Equal(t, 22, itr.Value())
+	assert.Equal(t, []int{10, 13, 16, 19}, itr.Collect())
+	itr.Reset()
+	assert.Equal(t, []int{10, 13, 16, 19, 22}, itr.Collect())
+
+	huge := iterator.Range(0, math.MaxInt64).Skip(math.MaxInt64 - 3)
+	assert.Equal(t, []int{math.MaxInt64 - 3, math.MaxInt64 - 2, math.MaxInt64 - 1}, huge.Collect())
+}
+
+func TestTakeRandomAccessConsumesSource(t *testing.T) {
+	source := iterator.Of(1, 2, 3, 4, 5)
+	assert.Equal(t, []int{1, 2}, source.Take(2).Collect())
+	assert.Equal(t, []int{3, 4, 5}, source.Collect())
+	source = iterator.Of(1, 2, 3, 4, 5)
+	assert.Equal(t, []int{3, 4}, source.Skip(2).Take(2).Collect())
+	assert.Equal(t, []int{5}, source.Collect())
+}
+
+func TestStepByRandomAccessSlice(t *testing.T) {
+	itr := iterator.Of(0, 1, 2, 3, 4, 5, 6, 7, 8, 9).StepBy(2).(iterator.RandomAccessIterator[int])
+	assert.Equal(t, []int{0, 2, 4, 6, 8}, []int{itr.At(0), itr.At(1), itr.At(2), itr.At(3), itr.At(4)})
+	assert.True(t, itr.Next())
+	assert.Equal(t, 4, itr.Len())
+	assert.Equal(t, 4, itr.At(1))
+	sub := itr.Slice(1, 3)
+	assert.Equal(t, []int{4, 6}, sub.Collect())
+	assert.Empty(t, itr.Slice(2, 2).Collect())
+	assert.Equal(t, []int{2, 4, 6, 8}, itr.Collect())
+}
+
+func TestSliceTrySplit(t *testing.T) {
+	itr := iterator.Of(1, 2, 3, 4, 5)
+	assert.True(t, itr.Next())
+	prefix, ok := itr.(iterator.Splittable[int]).TrySplit()
+	require.True(t, ok)
+	assert.Equal(t, []int{2, 3}, iterator.Collect(prefix))
+	assert.Equal(t, []int{4, 5}, itr.Collect())
+	_, ok = iterator.Of(1).(iterator.Splittable[int]).TrySplit()
+	assert.False(t, ok)
+}
+
+func TestRangeTrySplit(t *testing.T) {
+	itr := iterator.IncRangeBy(0, 20, 2)
+	assert.True(t, itr.(iterator.DoubleEndedIterator[int]).NextBack())
+	prefix, ok := itr.(iterator.Splittable[int]).TrySplit()
+	require.True(t, ok)
+	assert.Equal(t, []int{0, 2, 4, 6, 8}, iterator.Collect(prefix))
+	assert.Equal(t, []int{10, 12, 14, 16, 18}, itr.Collect())
+}
+
+func TestParFold(t *testing.T) {
+	const size = 100000
+	expected := iterator.Sum(iterator.Range(0, size))
+	for _, cpu := range []int{1, 2, 3, 8} {
+		actual := iterator.ParFold(iterator.Range(0, size), 0, functions.Sum, functions.Sum,
+			iterator.ParMaxCpu(cpu), slices.ParThreshold(100))
+		assert.Equal(t, expected, actual)
+	}
+	concat := func(a, b string) string { return a + b }
+	digits := iterator.ParFold(iterator.Range(0, 10), "", func(a string, e int) string { return a + strconv.Itoa(e) }, concat,
+		iterator.ParMaxCpu(4), iterator.ParThreshold(1))
+	assert.Equal(t, "0123456789", digits)
+	unsplit := iterator.ParFold(iterator.New(rangeSeq(0, 10, 1)), 0, functions.Sum, functions.Sum, iterator.ParThreshold(1))
+	assert.Equal(t, 45, unsplit)
+	assert.PanicsWithError(t, iterator.ErrSizeInfinite.Error(), func() {
+		iterator.ParFold(iterator.Repeat(1), 0, functions.Sum, functions.Sum)
+	})
+}
+
+func TestParFoldPanic(t *testing.T) {
+	defer func() {
+		var pp iterator.ParPanic
+		err, ok := recover().(error)
+		require.True(t, ok)
+		assert.ErrorAs(t, err, &pp)
+	}()
+	iterator.ParFold(iterator.Range(0, 100), 0, func(a, e int) int {
+		if e == 75 {
+			panic("bad element")
+		}
+		return a + e
+	}, functions.Sum, iterator.ParMaxCpu(2), iterator.ParThreshold(10))
+}
+
+func TestParForEach(t *testing.T) {
+	var mutex sync.Mutex
+	seen := make(map[int]bool)
+	iterator.ParForEach(iterator.Range(0, 1000), func(n int) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		seen[n] = true
+	}, iterator.ParMaxCpu(4), iterator.ParThreshold(10))
+	assert.Len(t, seen, 1000)
+}
+
+func TestParCollect(t *testing.T) {
+	input := slices.Range(0, 10000)
+	assert.Equal(t, input, iterator.ParCollect(iterator.Slice(input), iterator.ParMaxCpu(5), iterator.ParThreshold(100)))
+	assert.Equal(t, input, iterator.ParCollect(iterator.Range(0, 10000)))
+	assert.Equal(t, []int{0, 1, 2}, iterator.ParCollect(iterator.New(rangeSeq(0, 3, 1)), iterator.ParThreshold(0)))
+}
+
+func TestParFoldMap(t *testing.T) {
+	input := make(map[int]int)
+	for i := range 1000 {
+		input[i] = i * 2
+	}
+	actual := iterator.ParFold(maps.Iter(input), 0, functions.Sum, functions.Sum, iterator.ParMaxCpu(4), iterator.ParThreshold(10))
+	assert.Equal(t, 999*1000, actual)
+}
@@ -0,0 +1,312 @@
+package iterator
+
+import (
+	"container/heap"
+	"iter"
+
+	"github.com/robdavid/genutil-go/tuple"
+)
+
+// mergeItem is an element waiting in the heap of a k-way merge, along with
+// the index of the input it was read from.
+type mergeItem[T any] struct {
+	value  T
+	source int
+}
+
+// mergeHeap is a min-heap of merge items. Items that compare equal are
+// ordered by source index, so that the merge is stable.
+type mergeHeap[T any] struct {
+	items []mergeItem[T]
+	cmp   func(a, b T) int
+}
+
+func (mh *mergeHeap[T]) Len() int { return len(mh.items) }
+
+func (mh *mergeHeap[T]) Less(i, j int) bool {
+	if c := mh.cmp(mh.items[i].value, mh.items[j].value); c != 0 {
+		return c < 0
+	}
+	return mh.items[i].source < mh.items[j].source
+}
+
+func (mh *mergeHeap[T]) Swap(i, j int) { mh.items[i], mh.items[j] = mh.items[j], mh.items[i] }
+
+func (mh *mergeHeap[T]) Push(x any) { mh.items = append(mh.items, x.(mergeItem[T])) }
+
+func (mh *mergeHeap[T]) Pop() any {
+	last := len(mh.items) - 1
+	item := mh.items[last]
+	mh.items = mh.items[:last]
+	return item
+}
+
+type mergeSortedIter[T any] struct {
+	inputs  []CoreIterator[T]
+	heap    mergeHeap[T]
+	started bool
+	value   T
+}
+
+func (ms *mergeSortedIter[T]) start() {
+	ms.started = true
+	ms.heap.items = make([]mergeItem[T], 0, len(ms.inputs))
+	for i, input := range ms.inputs {
+		if input.Next() {
+			ms.heap.items = append(ms.heap.items, mergeItem[T]{input.Value(), i})
+		}
+	}
+	heap.Init(&ms.heap)
+}
+
+func (ms *mergeSortedIter[T]) Next() bool {
+	if !ms.started {
+		ms.start()
+	}
+	if ms.heap.Len() == 0 {
+		return false
+	}
+	top := &ms.heap.items[0]
+	ms.value = top.value
+	if input := ms.inputs[top.source]; input.Next() {
+		top.value = input.Value()
+		heap.Fix(&ms.heap, 0)
+	} else {
+		heap.Pop(&ms.heap)
+	}
+	return true
+}
+
+func (ms *mergeSortedIter[T]) Value() T {
+	return ms.value
+}
+
+func (ms *mergeSortedIter[T]) Abort() {
+	for _, input := range ms.inputs {
+		input.Abort()
+	}
+	ms.started = true
+	ms.heap.items = nil
+}
+
+func (ms *mergeSortedIter[T]) Reset() {
+	for _, input := range ms.inputs {
+		input.Reset()
+	}
+	ms.started = false
+	ms.heap.items = nil
+}
+
+// Size is the sum of the sizes of the inputs, plus the number of elements
+// already read from them that are waiting in the heap.
+func (ms *mergeSortedIter[T]) Size() IteratorSize {
+	size := NewSize(ms.heap.Len())
+	for _, input := range ms.inputs {
+		size = addSize(size, input.Size())
+	}
+	return size
+}
+
+func (ms *mergeSortedIter[T]) SeqOK() bool { return false }
+
+func (ms *mergeSortedIter[T]) Seq() iter.Seq[T] {
+	return Seq(ms)
+}
+
+// mergeJoinIter walks two iterators sorted by key in step. Each key from the
+// left iterator is looked up in the right one; if matched is true, pairs
+// with a matching key are produced, otherwise pairs without one are. The run
+// of right values sharing the most recently matched key is held in group, so
+// that repeated keys in left can be matched against it too. If cross is true,
+// each matching left value is combined with every value in the group, rather
+// than only the first.
+type mergeJoinIter[K any, A any, B any, V any] struct {
+	left      CoreIterator2[K, A]
+	right     CoreIterator2[K, B]
+	cmp       func(a, b K) int
+	matched   bool
+	cross     bool
+	combine   func(A, B) V
+	rightOK   bool
+	started   bool
+	done      bool
+	grouped   bool
+	groupKey  K
+	group     []B
+	leftValue A
+	pending   []B
+	key       K
+	value     V
+}
+
+// seek advances the right iterator to the first key not less than key, and
+// returns true if the group of right values with that key is not empty.
+func (mj *mergeJoinIter[K, A, B, V]) seek(key K) bool {
+	if mj.grouped && mj.cmp(mj.groupKey, key) == 0 {
+		return true
+	}
+	for mj.rightOK && mj.cmp(mj.right.Key(), key) < 0 {
+		mj.rightOK = mj.right.Next()
+	}
+	mj.grouped = false
+	mj.group = mj.group[:0]
+	for mj.rightOK && mj.cmp(mj.right.Key(), key) == 0 {
+		mj.grouped = true
+		mj.group = append(mj.group, mj.right.Value())
+		mj.rightOK = mj.right.Next()
+	}
+	mj.groupKey = key
+	return mj.grouped
+}
+
+func (mj *mergeJoinIter[K, A, B, V]) Next() bool {
+	if mj.done {
+		return false
+	}
+	if !mj.started {
+		mj.started = true
+		mj.rightOK = mj.right.Next()
+	}
+	if len(mj.pending) > 0 {
+		mj.value = mj.combine(mj.leftValue, mj.pending[0])
+		mj.pending = mj.pending[1:]
+		return true
+	}
+	for {
+		if mj.matched && !mj.rightOK && !mj.grouped {
+			// Nothing left to match against
+			mj.Abort()
+			return false
+		}
+		if !mj.left.Next() {
+			mj.Abort()
+			return false
+		}
+		key := mj.left.Key()
+		found := mj.seek(key)
+		if found == mj.matched {
+			var b B
+			if found {
+				b = mj.group[0]
+				if mj.cross {
+					mj.leftValue, mj.pending = mj.left.Value(), mj.group[1:]
+				}
+			}
+			mj.key, mj.value = key, mj.combine(mj.left.Value(), b)
+			return true
+		}
+	}
+}
+
+func (mj *mergeJoinIter[K, A, B, V]) Key() K {
+	return mj.key
+}
+
+func (mj *mergeJoinIter[K, A, B, V]) Value() V {
+	return mj.value
+}
+
+func (mj *mergeJoinIter[K, A, B, V]) Abort() {
+	mj.pending = nil
+	if !mj.done {
+		mj.done = true
+		mj.left.Abort()
+		mj.right.Abort()
+	}
+}
+
+func (mj *mergeJoinIter[K, A, B, V]) Reset() {
+	mj.left.Reset()
+	mj.right.Reset()
+	mj.started = false
+	mj.done = false
+	mj.grouped = false
+	mj.group = nil
+	mj.pending = nil
+}
+
+func (mj *mergeJoinIter[K, A, B, V]) Size() IteratorSize {
+	if mj.done {
+		return NewSize(0)
+	}
+	if mj.cross {
+		// Each left value may be paired with any number of right values
+		return NewSizeUnknown()
+	}
+	size := mj.left.Size()
+	if mj.matched && size.IsInfinite() && !mj.right.Size().IsInfinite() {
+		return NewSizeUnknown()
+	}
+	return size.Subset()
+}
+
+func (mj *mergeJoinIter[K, A, B, V]) SeqOK() bool { return false }
+
+func (mj *mergeJoinIter[K, A, B, V]) Seq() iter.Seq[V] {
+	return Seq(mj)
+}
+
+func (mj *mergeJoinIter[K, A, B, V]) Seq2() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for mj.Next() {
+			if !yield(mj.key, mj.value) {
+				mj.Abort()
+				break
+			}
+		}
+	}
+}
+
+// MergeSorted builds an iterator that merges the elements of several
+// iterators, each of which must already be sorted in ascending order according
+// to the comparison function cmp. The result is sorted in the same order.
+// Elements that compare as equal are produced in the order of the iterators
+// they come from. The merge is performed using a heap, so each element costs
+// O(log n) comparisons for n inputs. The size of the resulting iterator is the
+// sum of the sizes of the inputs; calling Abort() on it aborts all the inputs.
+// E.g.
+//
+//	itr := iterator.MergeSorted(cmp.Compare, iterator.Of(1, 4, 7), iterator.Of(2, 3, 8))
+//	result := itr.Collect() // []int{1,2,3,4,7,8}
+func MergeSorted[T any](cmp func(a, b T) int, itrs ...CoreIterator[T]) Iterator[T] {
+	return NewDefaultIterator(&mergeSortedIter[T]{inputs: itrs, heap: mergeHeap[T]{cmp: cmp}})
+}
+
+// MergeJoin joins two iterators of key/value pairs, each of which must already
+// be sorted in ascending order of key according to the comparison function
+// cmp. For each pair in left and each pair in right with the same key, a pair
+// of the key and a tuple of the two values is produced. Where a key is
+// repeated in both inputs, every combination of their values is produced, in
+// the order of left and then right; the values in right for the current key
+// are held in memory for this purpose. Both inputs are aborted once the result
+// is exhausted or aborted. E.g.
+//
+//	left := iterator.Zip(iterator.Of(1, 2, 2), iterator.Of("a", "b", "c"))
+//	right := iterator.Zip(iterator.Of(2, 2, 3), iterator.Of(1.0, 1.5, 3.0))
+//	itr := iterator.MergeJoin(cmp.Compare, left, right)
+//	result := itr.Collect() // b 1.0, b 1.5, c 1.0, c 1.5 (all with key 2)
+func MergeJoin[K any, A any, B any](cmp func(a, b K) int, left CoreIterator2[K, A], right CoreIterator2[K, B]) Iterator2[K, tuple.Tuple2[A, B]] {
+	return NewDefaultIterator2(&mergeJoinIter[K, A, B, tuple.Tuple2[A, B]]{
+		left: left, right: right, cmp: cmp, matched: true, cross: true, combine: tuple.Pair[A, B],
+	})
+}
+
+// Intersect produces the key/value pairs of left whose keys are also present
+// in right. Both iterators must already be sorted in ascending order of key
+// according to the comparison function cmp. Both inputs are aborted once the
+// result is exhausted or aborted.
+func Intersect[K any, V any, W any](cmp func(a, b K) int, left CoreIterator2[K, V], right CoreIterator2[K, W]) Iterator2[K, V] {
+	return NewDefaultIterator2(&mergeJoinIter[K, V, W, V]{
+		left: left, right: right, cmp: cmp, matched: true, combine: func(v V, _ W) V { return v },
+	})
+}
+
+// Difference produces the key/value pairs of left whose keys are not present
+// in right. Both iterators must already be sorted in ascending order of key
+// according to the comparison function cmp. Both inputs are aborted once the
+// result is exhausted or aborted.
+func Difference[K any, V any, W any](cmp func(a, b K) int, left CoreIterator2[K, V], right CoreIterator2[K, W]) Iterator2[K, V] {
+	return NewDefaultIterator2(&mergeJoinIter[K, V, W, V]{
+		left: left, right: right, cmp: cmp, matched: false, combine: func(v V, _ W) V { return v },
+	})
+}
@@ -0,0 +1,506 @@
+package iterator
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"math/bits"
+	"slices"
+	"sync"
+
+	"github.com/robdavid/genutil-go/functions"
+	"github.com/robdavid/genutil-go/internal/paroptions"
+)
+
+var ErrInvalidNumCPU = errors.New("invalid number of CPUs")
+
+// ParOption is an option that controls the parallel execution of the Par*
+// functions. Options created by the equivalent functions in the slices package
+// are also accepted.
+type ParOption = paroptions.Option
+
+// ParMaxCpu is an option that sets the maximum number of worker goroutines used to
+// process iterator elements in parallel. Defaults to `runtime.NumCPU()`.
+func ParMaxCpu(maxCpu int) ParOption { return func(o *paroptions.Options) { o.MaxCpu = maxCpu } }
+
+// ParThreshold is an option that sets the minimum number of elements beyond
+// which an iterator will be split so that its elements can be processed by
+// multiple goroutines in functions such as [ParFold]. Defaults to 100000.
+func ParThreshold(threshold int) ParOption {
+	return func(o *paroptions.Options) { o.Threshold = threshold }
+}
+
+// ParOrdered is an option that determines whether the elements produced by a
+// parallel iterator appear in the same order as the input elements they were
+// derived from (true), or in the order in which their processing completes
+// (false). Defaults to true.
+func ParOrdered(ordered bool) ParOption { return func(o *paroptions.Options) { o.Ordered = ordered } }
+
+// ParPanic is an error type indicating that a function executing as part of a
+// parallel iterator has panicked. The panic is raised again, wrapped in this
+// type, in the goroutine consuming the iterator.
+type ParPanic struct {
+	panic any
+}
+
+func (pp ParPanic) Error() string {
+	return fmt.Sprintf("panic in parallel iterator: %#v", pp.panic)
+}
+
+func (pp ParPanic) Unwrap() error {
+	if err, ok := pp.panic.(error); ok {
+		return err
+	} else {
+		return nil
+	}
+}
+
+type parJob[T any] struct {
+	index int
+	value T
+}
+
+type parResult[U any] struct {
+	index    int
+	value    U
+	ok       bool
+	panicked bool
+	panic    any
+}
+
+// parRun holds the state shared with the goroutines of a single run of a
+// parallel iterator.
+type parRun[U any] struct {
+	tokens    chan struct{}
+	results   chan parResult[U]
+	done      chan struct{}
+	fed       chan struct{}
+	worked    chan struct{}
+	stopped   bool
+	feedPanic any
+}
+
+// parIter is a core iterator that applies a mapping function to the elements
+// of a source iterator using a pool of worker goroutines. The source is
+// consumed by a single feeder goroutine. The number of elements in flight at
+// any one time is bounded by a pool of tokens, each of which is returned when
+// the consumer receives the corresponding result.
+type parIter[T, U any] struct {
+	source   CoreIterator[T]
+	mapping  funcNext[T, U]
+	sizeFunc func(IteratorSize) IteratorSize
+	opts     paroptions.Options
+	run      *parRun[U]
+	initial  IteratorSize
+	pending  map[int]parResult[U]
+	next     int
+	emitted  int
+	finished bool
+	value    U
+}
+
+func newParIter[T, U any](itr CoreIterator[T], mapping funcNext[T, U], sizeFunc func(IteratorSize) IteratorSize, opts []ParOption) *parIter[T, U] {
+	o := paroptions.Combine(opts)
+	if o.MaxCpu < 1 {
+		panic(fmt.Errorf("%w: %d", ErrInvalidNumCPU, o.MaxCpu))
+	}
+	return &parIter[T, U]{source: itr, mapping: mapping, sizeFunc: sizeFunc, opts: o}
+}
+
+func (pi *parIter[T, U]) start() {
+	workers := pi.opts.MaxCpu
+	inflight := workers * 2
+	run := &parRun[U]{
+		tokens:  make(chan struct{}, inflight),
+		results: make(chan parResult[U], inflight),
+		done:    make(chan struct{}),
+		fed:     make(chan struct{}),
+		worked:  make(chan struct{}),
+	}
+	jobs := make(chan parJob[T])
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for range workers {
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				run.results <- pi.work(job)
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(run.results)
+		close(run.worked)
+	}()
+	pi.initial = pi.source.Size()
+	pi.pending = make(map[int]parResult[U])
+	pi.run = run
+	go pi.feed(run, jobs)
+}
+
+// feed runs in its own goroutine, passing elements from the source iterator
+// to the workers until either the source is exhausted or the run is stopped,
+// in which case the source is aborted.
+func (pi *parIter[T, U]) feed(run *parRun[U], jobs chan<- parJob[T]) {
+	defer close(run.fed)
+	defer close(jobs)
+	defer func() {
+		if p := recover(); p != nil {
+			run.feedPanic = p
+		}
+	}()
+	index := 0
+	send := func(v T) bool {
+		if isDone(run.done) {
+			return false
+		}
+		select {
+		case run.tokens <- struct{}{}:
+		case <-run.done:
+			return false
+		}
+		jobs <- parJob[T]{index, v}
+		index++
+		return true
+	}
+	if pi.source.SeqOK() {
+		for v := range pi.source.Seq() {
+			if !send(v) {
+				break
+			}
+		}
+	} else {
+		for !isDone(run.done) && pi.source.Next() {
+			if !send(pi.source.Value()) {
+				break
+			}
+		}
+	}
+	if isDone(run.done) {
+		pi.source.Abort()
+	}
+}
+
+func (pi *parIter[T, U]) work(job parJob[T]) (res parResult[U]) {
+	res.index = job.index
+	defer func() {
+		if p := recover(); p != nil {
+			res.panicked = true
+			res.panic = p
+		}
+	}()
+	res.value, res.ok = pi.mapping(job.value)
+	return
+}
+
+// receive obtains the next result in the order required by the options, or
+// returns false if there are no more results.
+func (pi *parIter[T, U]) receive() (parResult[U], bool) {
+	if !pi.opts.Ordered {
+		res, ok := <-pi.run.results
+		return res, ok
+	}
+	for {
+		if res, ok := pi.pending[pi.next]; ok {
+			delete(pi.pending, pi.next)
+			pi.next++
+			return res, true
+		}
+		res, ok := <-pi.run.results
+		if !ok {
+			return res, false
+		}
+		if res.panicked {
+			return res, true
+		}
+		if res.index == pi.next {
+			pi.next++
+			return res, true
+		}
+		pi.pending[res.index] = res
+	}
+}
+
+func (pi *parIter[T, U]) Next() bool {
+	if pi.finished {
+		return false
+	}
+	if pi.run == nil {
+		pi.start()
+	}
+	for {
+		res, ok := pi.receive()
+		if !ok {
+			pi.finished = true
+			if p := pi.run.feedPanic; p != nil {
+				panic(ParPanic{p})
+			}
+			return false
+		}
+		<-pi.run.tokens
+		if res.panicked {
+			pi.Abort()
+			panic(ParPanic{res.panic})
+		}
+		if res.ok {
+			pi.value = res.value
+			pi.emitted++
+			return true
+		}
+	}
+}
+
+func (pi *parIter[T, U]) Value() U {
+	return pi.value
+}
+
+// Abort stops the iterator and waits for the feeder goroutine to abort the
+// source iterator, and for the workers to finish any calls to the mapping
+// function that are in progress.
+func (pi *parIter[T, U]) Abort() {
+	pi.finished = true
+	if pi.run == nil {
+		pi.source.Abort()
+	} else if !pi.run.stopped {
+		pi.run.stopped = true
+		close(pi.run.done)
+		<-pi.run.fed
+		<-pi.run.worked
+	}
+}
+
+func (pi *parIter[T, U]) Reset() {
+	pi.Abort()
+	pi.source.Reset()
+	pi.run = nil
+	pi.pending = nil
+	pi.next = 0
+	pi.emitted = 0
+	pi.finished = false
+}
+
+func (pi *parIter[T, U]) Size() IteratorSize {
+	if pi.run == nil {
+		return pi.sizeFunc(pi.source.Size())
+	}
+	if pi.finished {
+		return NewSize(0)
+	}
+	switch pi.initial.Type {
+	case SizeKnown:
+		return pi.sizeFunc(NewSize(pi.initial.Size - pi.emitted))
+	case SizeAtMost:
+		return pi.sizeFunc(NewSizeMax(pi.initial.Size - pi.emitted))
+	default:
+		return pi.sizeFunc(pi.initial)
+	}
+}
+
+func (pi *parIter[T, U]) Seq() iter.Seq[U] {
+	return Seq(pi)
+}
+
+func (pi *parIter[T, U]) SeqOK() bool { return false }
+
+// ParMap applies function mapping to each value of an iterator, producing a
+// new iterator over the results. The mapping function is executed
+// concurrently by a pool of worker goroutines, which is started when the
+// first element is requested. By default, results are produced in the same
+// order as their corresponding inputs; the [ParOrdered] option may be used to
+// produce results as soon as they are available instead. The number of
+// workers may be set with the [ParMaxCpu] option.
+//
+// The source iterator is consumed in a separate goroutine; calling Abort() on
+// the returned iterator stops the workers and aborts the source iterator, and
+// returns once no call to mapping is still running. If
+// mapping panics, the panic is raised again in the consuming goroutine wrapped
+// in a [ParPanic] error. Note that the iterator should be consumed to the end
+// or aborted to ensure all goroutines terminate.
+func ParMap[T any, U any](itr CoreIterator[T], mapping func(T) U, opts ...ParOption) Iterator[U] {
+	mapNext := func(value T) (U, bool) {
+		return mapping(value), true
+	}
+	return NewDefaultIterator(newParIter(itr, mapNext, functions.Id, opts))
+}
+
+// ParFilter applies a filter function predicate to each element of an
+// iterator, producing a new iterator containing only the elements that satisfy
+// the function. The predicate is executed concurrently by a pool of worker
+// goroutines, in the same manner as [ParMap].
+func ParFilter[T any](itr CoreIterator[T], predicate func(T) bool, opts ...ParOption) Iterator[T] {
+	filterNext := func(value T) (T, bool) {
+		return value, predicate(value)
+	}
+	return NewDefaultIterator(newParIter(itr, filterNext, func(sz IteratorSize) IteratorSize { return sz.Subset() }, opts))
+}
+
+// ParFilterMap applies both transformation and filtering logic to an
+// iterator, as [FilterMap] does. The mapping function is executed
+// concurrently by a pool of worker goroutines, in the same manner as [ParMap].
+func ParFilterMap[T any, U any](itr CoreIterator[T], mapping func(T) (U, bool), opts ...ParOption) Iterator[U] {
+	return NewDefaultIterator(newParIter(itr, mapping, func(sz IteratorSize) IteratorSize { return sz.Subset() }, opts))
+}
+
+// asSplittable returns the [Splittable] implementation of an iterator, looking
+// inside the default wrapper types, if there is one.
+func asSplittable[T any](itr CoreIterator[T]) (Splittable[T], bool) {
+	switch it := itr.(type) {
+	case Splittable[T]:
+		return it, true
+	case DefaultIterator[T]:
+		return asSplittable(it.CoreIterator)
+	case DefaultMutableIterator[T]:
+		return asSplittable[T](it.CoreMutableIterator)
+	case DefaultDoubleEndedIterator[T]:
+		return asSplittable[T](it.CoreDoubleEndedIterator)
+	}
+	return nil, false
+}
+
+// splitHalf implements [Splittable] for a random access iterator, by taking a
+// slice of the first half of its remaining elements and advancing past them.
+func splitHalf[T any](ra interface {
+	CoreRandomAccessIterator[T]
+	advancer
+}) (CoreIterator[T], bool) {
+	n := ra.Len()
+	if n < 2 {
+		return nil, false
+	}
+	prefix := ra.Slice(0, n/2)
+	ra.advance(n / 2)
+	return prefix, true
+}
+
+// parSplit divides an iterator into parts, in order, to be consumed in
+// parallel. A part is split again while it has more elements than the
+// threshold, until there are enough parts to occupy MaxCpu goroutines. An
+// iterator that cannot be split is returned as the only part.
+func parSplit[T any](itr CoreIterator[T], o paroptions.Options) []CoreIterator[T] {
+	if o.MaxCpu < 1 {
+		panic(fmt.Errorf("%w: %d", ErrInvalidNumCPU, o.MaxCpu))
+	}
+	if itr.Size().IsInfinite() {
+		panic(ErrSizeInfinite)
+	}
+	var split func(part CoreIterator[T], depth int)
+	var parts []CoreIterator[T]
+	split = func(part CoreIterator[T], depth int) {
+		if size := part.Size(); depth > 0 && size.IsKnown() && size.Size > o.Threshold {
+			if s, ok := asSplittable(part); ok {
+				if prefix, ok := s.TrySplit(); ok {
+					split(prefix, depth-1)
+					split(part, depth-1)
+					return
+				}
+			}
+		}
+		parts = append(parts, part)
+	}
+	split(itr, bits.Len(uint(o.MaxCpu-1)))
+	return parts
+}
+
+// parEach calls f for each of parts, running no more than maxCpu goroutines at
+// once, and waits for them all to complete. If there is only one part, f is
+// called in the current goroutine. Otherwise, if f panics, the panic is
+// raised again in the current goroutine wrapped in a [ParPanic] error.
+func parEach[T any](parts []CoreIterator[T], maxCpu int, f func(i int, part CoreIterator[T])) {
+	if len(parts) == 1 {
+		f(0, parts[0])
+		return
+	}
+	tokens := make(chan struct{}, maxCpu)
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	var panicked *ParPanic
+	for i, part := range parts {
+		tokens <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				if p := recover(); p != nil {
+					mutex.Lock()
+					if panicked == nil {
+						panicked = &ParPanic{p}
+					}
+					mutex.Unlock()
+				}
+				<-tokens
+				wg.Done()
+			}()
+			f(i, part)
+		}()
+	}
+	wg.Wait()
+	if panicked != nil {
+		panic(*panicked)
+	}
+}
+
+// ParFold combines the elements of an iterator into a single value, as
+// [Fold] does, potentially using multiple goroutines. If the iterator is
+// [Splittable] and has more elements than the threshold set by the
+// [ParThreshold] option, it is split into parts, up to the number of
+// goroutines set by the [ParMaxCpu] option, and each part is folded
+// separately, starting from init. The results of the parts are then combined
+// in order using the combine function. Therefore init should be an identity
+// value for both f and combine, and combine should be associative. Iterators
+// that cannot be split are folded in the current goroutine. The options
+// created by the equivalent functions in the slices package are also accepted.
+// If f panics in another goroutine, the panic is raised again wrapped in a
+// [ParPanic] error. If the iterator is known to be of infinite size, this
+// function will panic with [ErrSizeInfinite]. E.g.
+//
+//	sum := iterator.ParFold(iterator.Range(0, 1000000), 0, functions.Sum, functions.Sum)
+func ParFold[U any, T any](itr CoreIterator[T], init U, f func(a U, e T) U, combine func(a, b U) U, opts ...ParOption) U {
+	o := paroptions.Combine(opts)
+	parts := parSplit(itr, o)
+	results := make([]U, len(parts))
+	parEach(parts, o.MaxCpu, func(i int, part CoreIterator[T]) {
+		results[i] = Fold(part, init, f)
+	})
+	acc := results[0]
+	for _, result := range results[1:] {
+		acc = combine(acc, result)
+	}
+	return acc
+}
+
+// ParForEach calls f for each element of an iterator, potentially using
+// multiple goroutines. The iterator is split in the same manner as [ParFold],
+// and the elements of each part are passed to f in order, but the parts are
+// processed concurrently, so f must be safe to call from multiple goroutines.
+func ParForEach[T any](itr CoreIterator[T], f func(T), opts ...ParOption) {
+	o := paroptions.Combine(opts)
+	parEach(parSplit(itr, o), o.MaxCpu, func(_ int, part CoreIterator[T]) {
+		if part.SeqOK() {
+			for e := range part.Seq() {
+				f(e)
+			}
+		} else {
+			for part.Next() {
+				f(part.Value())
+			}
+		}
+	})
+}
+
+// ParCollect collects the elements of an iterator into a slice, as [Collect]
+// does, potentially using multiple goroutines. The iterator is split in the
+// same manner as [ParFold], and each part is collected separately before the
+// results are joined, so the elements of the slice are in the same order as
+// the iterator.
+func ParCollect[T any](itr CoreIterator[T], opts ...ParOption) []T {
+	o := paroptions.Combine(opts)
+	parts := parSplit(itr, o)
+	if len(parts) == 1 {
+		return Collect(itr)
+	}
+	results := make([][]T, len(parts))
+	parEach(parts, o.MaxCpu, func(i int, part CoreIterator[T]) {
+		results[i] = Collect(part)
+	})
+	return slices.Concat(results...)
+}
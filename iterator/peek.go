@@ -0,0 +1,141 @@
+package iterator
+
+import (
+	"iter"
+	"slices"
+)
+
+// Peekable is an [Iterator] that supports lookahead, allowing elements to be
+// examined before they are consumed, and consumed elements to be returned to
+// the iterator. It is useful for building parsers over iterators of tokens.
+type Peekable[T any] interface {
+	Iterator[T]
+
+	// Peek returns the next element of the iterator without consuming it,
+	// along with true. If there are no more elements, the zero value and false
+	// are returned.
+	Peek() (T, bool)
+
+	// PeekN returns up to the next n elements of the iterator without
+	// consuming them. Fewer than n elements are returned only if the iterator
+	// has fewer than n elements remaining. The returned slice is newly
+	// allocated.
+	PeekN(n int) []T
+
+	// PushBack returns an element to the front of the iterator, so that it is
+	// the next element produced. Any number of elements may be pushed back;
+	// they are produced in the reverse of the order in which they were pushed.
+	PushBack(v T)
+
+	// NextIf consumes the next element of the iterator only if it satisfies
+	// the predicate p, in which case the element is returned along with true.
+	// Otherwise, the zero value and false are returned and the element remains
+	// in the iterator.
+	NextIf(p func(T) bool) (T, bool)
+}
+
+// peekIter is a core iterator which holds elements that have been read from
+// the underlying iterator by a peek, or pushed back, in the ahead queue. These
+// are produced before any further elements of the underlying iterator.
+type peekIter[T any] struct {
+	base  CoreIterator[T]
+	ahead []T
+	value T
+}
+
+func (pi *peekIter[T]) Next() bool {
+	if len(pi.ahead) > 0 {
+		pi.value = pi.ahead[0]
+		pi.ahead = pi.ahead[1:]
+		return true
+	}
+	if pi.base.Next() {
+		pi.value = pi.base.Value()
+		return true
+	}
+	return false
+}
+
+func (pi *peekIter[T]) Value() T {
+	return pi.value
+}
+
+func (pi *peekIter[T]) Abort() {
+	pi.ahead = nil
+	pi.base.Abort()
+}
+
+func (pi *peekIter[T]) Reset() {
+	pi.ahead = nil
+	pi.base.Reset()
+}
+
+func (pi *peekIter[T]) Size() IteratorSize {
+	return addSize(NewSize(len(pi.ahead)), pi.base.Size())
+}
+
+// SeqOK is false since elements may be peeked or pushed back while a range
+// loop over Seq() is in progress.
+func (pi *peekIter[T]) SeqOK() bool { return false }
+
+// Seq returns an iterator built on Next(). Breaking out of a loop over it does
+// not abort the iterator, so that consumption may continue afterwards.
+func (pi *peekIter[T]) Seq() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for pi.Next() {
+			if !yield(pi.value) {
+				return
+			}
+		}
+	}
+}
+
+func (pi *peekIter[T]) PeekN(n int) []T {
+	for len(pi.ahead) < n && pi.base.Next() {
+		pi.ahead = append(pi.ahead, pi.base.Value())
+	}
+	return slices.Clone(pi.ahead[:min(n, len(pi.ahead))])
+}
+
+func (pi *peekIter[T]) Peek() (T, bool) {
+	if len(pi.ahead) == 0 {
+		if !pi.base.Next() {
+			var zero T
+			return zero, false
+		}
+		pi.ahead = append(pi.ahead, pi.base.Value())
+	}
+	return pi.ahead[0], true
+}
+
+func (pi *peekIter[T]) PushBack(v T) {
+	pi.ahead = slices.Insert(pi.ahead, 0, v)
+}
+
+func (pi *peekIter[T]) NextIf(p func(T) bool) (T, bool) {
+	if v, ok := pi.Peek(); ok && p(v) {
+		pi.Next()
+		return v, true
+	}
+	var zero T
+	return zero, false
+}
+
+type peekable[T any] struct {
+	*peekIter[T]
+	DefaultIterator[T]
+}
+
+// NewPeekable wraps an iterator to create a [Peekable] iterator, which
+// supports lookahead via its Peek, PeekN, PushBack and NextIf methods. The
+// size of the returned iterator includes any elements that have been peeked
+// or pushed back. E.g.
+//
+//	itr := iterator.NewPeekable(iterator.Of(1, 2, 3))
+//	first, _ := itr.Peek()  // 1
+//	itr.PushBack(0)
+//	result := itr.Collect() // []int{0,1,2,3}
+func NewPeekable[T any](itr CoreIterator[T]) Peekable[T] {
+	core := &peekIter[T]{base: itr}
+	return peekable[T]{peekIter: core, DefaultIterator: NewDefaultIterator[T](core)}
+}
@@ -0,0 +1,298 @@
+package iterator
+
+import (
+	"fmt"
+	"iter"
+)
+
+// DefaultRandomAccessIterator wraps a [CoreRandomAccessIterator] together with
+// a [DefaultIterator] to provide an implementation of [RandomAccessIterator].
+type DefaultRandomAccessIterator[T any] struct {
+	CoreRandomAccessIterator[T]
+	DefaultIterator[T]
+}
+
+// NewDefaultRandomAccessIterator builds a [RandomAccessIterator] from a
+// [CoreRandomAccessIterator] by adding the methods of [IteratorExtensions].
+func NewDefaultRandomAccessIterator[T any](citr CoreRandomAccessIterator[T]) DefaultRandomAccessIterator[T] {
+	return DefaultRandomAccessIterator[T]{CoreRandomAccessIterator: citr, DefaultIterator: DefaultIterator[T]{CoreIterator: citr}}
+}
+
+// TrySplit divides the remaining elements of the underlying iterator if it is
+// [Splittable], and otherwise returns false.
+func (dra DefaultRandomAccessIterator[T]) TrySplit() (CoreIterator[T], bool) {
+	if s, ok := dra.CoreRandomAccessIterator.(Splittable[T]); ok {
+		return s.TrySplit()
+	}
+	return nil, false
+}
+
+// asRandomAccess returns the random access core of an iterator, looking inside
+// the default wrapper types, if there is one.
+func asRandomAccess[T any](itr CoreIterator[T]) (CoreRandomAccessIterator[T], bool) {
+	switch it := itr.(type) {
+	case DefaultRandomAccessIterator[T]:
+		return it.CoreRandomAccessIterator, true
+	case CoreRandomAccessIterator[T]:
+		return it, true
+	case DefaultIterator[T]:
+		return asRandomAccess(it.CoreIterator)
+	case DefaultMutableIterator[T]:
+		return asRandomAccess[T](it.CoreMutableIterator)
+	case DefaultDoubleEndedIterator[T]:
+		return asRandomAccess[T](it.CoreDoubleEndedIterator)
+	}
+	return nil, false
+}
+
+// advancer is implemented by random access iterators that can discard
+// elements from the front in constant time.
+type advancer interface {
+	advance(n int)
+}
+
+// advanceBy discards up to n elements from the front of an iterator.
+func advanceBy[T any](itr CoreIterator[T], n int) {
+	if adv, ok := itr.(advancer); ok {
+		adv.advance(n)
+		return
+	}
+	for ; n > 0 && itr.Next(); n-- {
+	}
+}
+
+func checkIndex(i, n int) {
+	if i < 0 || i >= n {
+		panic(fmt.Errorf("%w: index %d out of range with length %d", ErrInvalidIteratorRange, i, n))
+	}
+}
+
+func checkSlice(i, j, n int) {
+	if i < 0 || j < i || j > n {
+		panic(fmt.Errorf("%w: slice bounds [%d:%d] out of range with length %d", ErrInvalidIteratorRange, i, j, n))
+	}
+}
+
+// takeRandomIter is the random access equivalent of takeIterator. Elements
+// taken from the front are consumed from the underlying iterator; those taken
+// from the back are read by position, since the underlying iterator may
+// extend beyond the end of the ones taken.
+type takeRandomIter[T any] struct {
+	source     CoreRandomAccessIterator[T]
+	max, count int
+	back       int
+	value      T
+	aborted    bool
+}
+
+func (tr *takeRandomIter[T]) Len() int {
+	if tr.aborted {
+		return 0
+	}
+	return max(0, min(tr.max-tr.count, tr.source.Len())-tr.back)
+}
+
+func (tr *takeRandomIter[T]) Next() bool {
+	if tr.Len() == 0 || !tr.source.Next() {
+		return false
+	}
+	tr.count++
+	tr.value = tr.source.Value()
+	return true
+}
+
+func (tr *takeRandomIter[T]) NextBack() bool {
+	n := tr.Len()
+	if n == 0 {
+		return false
+	}
+	tr.value = tr.source.At(n - 1)
+	tr.back++
+	return true
+}
+
+func (tr *takeRandomIter[T]) At(i int) T {
+	checkIndex(i, tr.Len())
+	return tr.source.At(i)
+}
+
+func (tr *takeRandomIter[T]) Slice(i, j int) RandomAccessIterator[T] {
+	checkSlice(i, j, tr.Len())
+	return tr.source.Slice(i, j)
+}
+
+func (tr *takeRandomIter[T]) Value() T {
+	return tr.value
+}
+
+func (tr *takeRandomIter[T]) Abort() {
+	if !tr.aborted {
+		tr.aborted = true
+		tr.source.Abort()
+	}
+}
+
+func (tr *takeRandomIter[T]) Reset() {
+	tr.count, tr.back = 0, 0
+	tr.aborted = false
+	tr.source.Reset()
+}
+
+func (tr *takeRandomIter[T]) Size() IteratorSize {
+	return NewSize(tr.Len())
+}
+
+func (tr *takeRandomIter[T]) SeqOK() bool { return false }
+
+func (tr *takeRandomIter[T]) Seq() iter.Seq[T] {
+	return Seq(tr)
+}
+
+// skipRandomIter is the random access equivalent of skipIterator. The
+// elements to be skipped are discarded from the underlying iterator in one
+// step when the first element is requested.
+type skipRandomIter[T any] struct {
+	source  CoreRandomAccessIterator[T]
+	n, skip int
+	back    int
+	value   T
+}
+
+func (sr *skipRandomIter[T]) Len() int {
+	return max(0, sr.source.Len()-sr.skip-sr.back)
+}
+
+func (sr *skipRandomIter[T]) Next() bool {
+	if sr.skip > 0 {
+		advanceBy[T](sr.source, sr.skip)
+		sr.skip = 0
+	}
+	if sr.Len() == 0 || !sr.source.Next() {
+		return false
+	}
+	sr.value = sr.source.Value()
+	return true
+}
+
+func (sr *skipRandomIter[T]) NextBack() bool {
+	n := sr.Len()
+	if n == 0 {
+		return false
+	}
+	sr.value = sr.source.At(sr.skip + n - 1)
+	sr.back++
+	return true
+}
+
+func (sr *skipRandomIter[T]) At(i int) T {
+	checkIndex(i, sr.Len())
+	return sr.source.At(sr.skip + i)
+}
+
+func (sr *skipRandomIter[T]) Slice(i, j int) RandomAccessIterator[T] {
+	checkSlice(i, j, sr.Len())
+	return sr.source.Slice(sr.skip+i, sr.skip+j)
+}
+
+func (sr *skipRandomIter[T]) Value() T {
+	return sr.value
+}
+
+func (sr *skipRandomIter[T]) Abort() {
+	sr.skip, sr.back = 0, 0
+	sr.source.Abort()
+}
+
+func (sr *skipRandomIter[T]) Reset() {
+	sr.skip, sr.back = sr.n, 0
+	sr.source.Reset()
+}
+
+func (sr *skipRandomIter[T]) Size() IteratorSize {
+	return NewSize(sr.Len())
+}
+
+func (sr *skipRandomIter[T]) SeqOK() bool { return false }
+
+func (sr *skipRandomIter[T]) Seq() iter.Seq[T] {
+	return Seq(sr)
+}
+
+// stepByRandomIter is the random access equivalent of stepByIterator. The
+// elements between steps are discarded from the underlying iterator in one
+// step.
+type stepByRandomIter[T any] struct {
+	source        CoreRandomAccessIterator[T]
+	step, pending int
+	back          int
+	value         T
+}
+
+func (sr *stepByRandomIter[T]) Len() int {
+	size := sr.source.Len()
+	if size <= sr.pending {
+		return 0
+	}
+	return max(0, ceilDiv(size-sr.pending, sr.step)-sr.back)
+}
+
+func (sr *stepByRandomIter[T]) Next() bool {
+	if sr.Len() == 0 {
+		return false
+	}
+	advanceBy[T](sr.source, sr.pending)
+	if !sr.source.Next() {
+		return false
+	}
+	sr.value = sr.source.Value()
+	sr.pending = sr.step - 1
+	return true
+}
+
+func (sr *stepByRandomIter[T]) NextBack() bool {
+	n := sr.Len()
+	if n == 0 {
+		return false
+	}
+	sr.value = sr.source.At(sr.pending + (n-1)*sr.step)
+	sr.back++
+	return true
+}
+
+func (sr *stepByRandomIter[T]) At(i int) T {
+	checkIndex(i, sr.Len())
+	return sr.source.At(sr.pending + i*sr.step)
+}
+
+func (sr *stepByRandomIter[T]) Slice(i, j int) RandomAccessIterator[T] {
+	checkSlice(i, j, sr.Len())
+	if i == j {
+		return sr.source.Slice(0, 0)
+	}
+	view := sr.source.Slice(sr.pending+i*sr.step, sr.pending+(j-1)*sr.step+1)
+	return NewDefaultRandomAccessIterator[T](&stepByRandomIter[T]{source: view, step: sr.step})
+}
+
+func (sr *stepByRandomIter[T]) Value() T {
+	return sr.value
+}
+
+func (sr *stepByRandomIter[T]) Abort() {
+	sr.pending, sr.back = 0, 0
+	sr.source.Abort()
+}
+
+func (sr *stepByRandomIter[T]) Reset() {
+	sr.pending, sr.back = 0, 0
+	sr.source.Reset()
+}
+
+func (sr *stepByRandomIter[T]) Size() IteratorSize {
+	return NewSize(sr.Len())
+}
+
+func (sr *stepByRandomIter[T]) SeqOK() bool { return false }
+
+func (sr *stepByRandomIter[T]) Seq() iter.Seq[T] {
+	return Seq(sr)
+}
@@ -12,12 +12,14 @@ import (
 type rangeIterInitial[T ordered.Real] struct {
 	index     T
 	inclusive bool
+	back      int
 }
 type rangeIter[T ordered.Real, S ordered.Real] struct {
 	index, to T
 	by        S
 	value     T
 	inclusive bool
+	back      int // number of elements consumed from the back
 	initial   rangeIterInitial[T]
 }
 
@@ -39,6 +41,9 @@ func (ri *rangeIter[T, S]) validateRange() {
 }
 
 func (ri *rangeIter[T, S]) Next() bool {
+	if ri.back > 0 && ri.Size().Size == 0 {
+		return false
+	}
 	if ri.index == ri.to {
 		// Handles the case where by is zero, which is valid if index is at the end
 		if ri.inclusive {
@@ -57,6 +62,27 @@ func (ri *rangeIter[T, S]) Next() bool {
 	return true
 }
 
+// NextBack computes the last element remaining from the first one and the
+// number remaining, rather than by stepping back from the end of the range,
+// which may not itself be an element.
+func (ri *rangeIter[T, S]) NextBack() bool {
+	remain := ri.Size().Size
+	if remain == 0 {
+		return false
+	}
+	ri.value = ri.index
+	if remain > 1 {
+		_, aStep := rangehelper.RangeSize(ri.index, ri.to, ri.by, ri.inclusive)
+		if ri.by < 0 {
+			ri.value -= T(remain-1) * aStep
+		} else {
+			ri.value += T(remain-1) * aStep
+		}
+	}
+	ri.back++
+	return true
+}
+
 func (ri *rangeIter[T, S]) Value() T {
 	return ri.value
 }
@@ -64,23 +90,71 @@ func (ri *rangeIter[T, S]) Value() T {
 func (ri *rangeIter[T, S]) Abort() {
 	ri.index = ri.to
 	ri.inclusive = false
+	ri.back = 0
 }
 
 func (ri *rangeIter[T, S]) Reset() {
 	ri.index = ri.initial.index
 	ri.inclusive = ri.initial.inclusive
+	ri.back = ri.initial.back
+}
+
+// offset returns the value i steps on from the next value in the range.
+func (ri *rangeIter[T, S]) offset(i int) T {
+	switch {
+	case i == 0:
+		return ri.index
+	case ri.by < 0:
+		return ri.index - T(i)*T(-ri.by)
+	default:
+		return ri.index + T(i)*T(ri.by)
+	}
+}
+
+func (ri *rangeIter[T, S]) Len() int {
+	return ri.Size().Size
+}
+
+func (ri *rangeIter[T, S]) At(i int) T {
+	checkIndex(i, ri.Len())
+	return ri.offset(i)
+}
+
+// Slice produces a range starting from the value at position i, which retains
+// the end of this range and excludes the values from position j onwards by
+// treating them as already consumed from the back.
+func (ri *rangeIter[T, S]) Slice(i, j int) RandomAccessIterator[T] {
+	n := ri.Len()
+	checkSlice(i, j, n)
+	index, back := ri.offset(i), ri.back+n-j
+	sub := rangeIter[T, S]{index: index, to: ri.to, by: ri.by, inclusive: ri.inclusive, back: back,
+		initial: rangeIterInitial[T]{index: index, inclusive: ri.inclusive, back: back}}
+	return NewDefaultRandomAccessIterator[T](&sub)
+}
+
+func (ri *rangeIter[T, S]) advance(n int) {
+	if n >= ri.Len() {
+		ri.Abort()
+	} else {
+		ri.index = ri.offset(n)
+	}
+}
+
+func (ri *rangeIter[T, S]) TrySplit() (CoreIterator[T], bool) {
+	return splitHalf[T](ri)
 }
 
 func (ri *rangeIter[T, S]) Seq() iter.Seq[T] {
 	return func(yield func(T) bool) {
 		defer ri.Abort()
 		if ri.index == ri.to {
-			if ri.inclusive {
+			if ri.inclusive && ri.back == 0 {
 				yield(ri.index)
 			}
 			return
 		}
 		size, aStep := rangehelper.RangeSize(ri.index, ri.to, ri.by, ri.inclusive)
+		size -= ri.back
 		if ri.by < 0 {
 			for range size {
 				index := ri.index
@@ -111,7 +185,7 @@ func (ri *rangeIter[T, S]) Size() IteratorSize {
 	} else {
 		size, _ = rangehelper.RangeSize(ri.index, ri.to, ri.by, ri.inclusive)
 	}
-	return NewSize(size)
+	return NewSize(max(size-ri.back, 0))
 }
 
 func (ri *rangeIter[T, S]) SeqOK() bool { return false }
@@ -120,7 +194,7 @@ func newRangeIter[T ordered.Real, S ordered.Real](from, upto T, by S, inclusive
 	itr := rangeIter[T, S]{index: from, to: upto, by: by, inclusive: inclusive,
 		initial: rangeIterInitial[T]{index: from, inclusive: inclusive}}
 	itr.validateRange()
-	return NewDefaultIterator(&itr)
+	return NewDefaultRandomAccessIterator[T](&itr)
 }
 
 // Range creates an iterator that produces a sequence of numeric values that
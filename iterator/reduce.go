@@ -0,0 +1,129 @@
+package iterator
+
+import (
+	"cmp"
+	"math"
+
+	"github.com/robdavid/genutil-go/functions"
+	"github.com/robdavid/genutil-go/opt"
+	"github.com/robdavid/genutil-go/ordered"
+)
+
+// Statistics holds summary statistics for a set of numeric values, as
+// computed by [Stats].
+type Statistics[T ordered.Real] struct {
+	Count    int     // Count is the number of values.
+	Min      T       // Min is the smallest value.
+	Max      T       // Max is the largest value.
+	Sum      float64 // Sum is the sum of the values.
+	Mean     float64 // Mean is the arithmetic mean of the values.
+	Variance float64 // Variance is the population variance of the values.
+}
+
+// StdDev returns the population standard deviation of the values, which is
+// the square root of the variance.
+func (s Statistics[T]) StdDev() float64 {
+	return math.Sqrt(s.Variance)
+}
+
+// MinBy returns the smallest element of an iterator, according to the
+// comparison function cmp. If there is more than one smallest element, the
+// first is returned. If the iterator is empty, an empty value is returned. If
+// the iterator is known to be of infinite size, this function will panic with
+// [ErrSizeInfinite].
+func MinBy[T any](itr CoreIterator[T], cmp func(a, b T) int) opt.Val[T] {
+	return Fold(itr, opt.Empty[T](), func(m opt.Val[T], e T) opt.Val[T] {
+		if v, ok := m.GetOK(); !ok || cmp(e, v) < 0 {
+			return opt.Value(e)
+		}
+		return m
+	})
+}
+
+// MaxBy returns the largest element of an iterator, according to the
+// comparison function cmp. If there is more than one largest element, the
+// first is returned. If the iterator is empty, an empty value is returned. If
+// the iterator is known to be of infinite size, this function will panic with
+// [ErrSizeInfinite].
+func MaxBy[T any](itr CoreIterator[T], cmp func(a, b T) int) opt.Val[T] {
+	return Fold(itr, opt.Empty[T](), func(m opt.Val[T], e T) opt.Val[T] {
+		if v, ok := m.GetOK(); !ok || cmp(e, v) > 0 {
+			return opt.Value(e)
+		}
+		return m
+	})
+}
+
+// Min returns the smallest element of an iterator. If the iterator is empty,
+// an empty value is returned. If the iterator is known to be of infinite size,
+// this function will panic with [ErrSizeInfinite].
+func Min[T cmp.Ordered](itr CoreIterator[T]) opt.Val[T] {
+	return MinBy(itr, cmp.Compare[T])
+}
+
+// Max returns the largest element of an iterator. If the iterator is empty, an
+// empty value is returned. If the iterator is known to be of infinite size,
+// this function will panic with [ErrSizeInfinite].
+func Max[T cmp.Ordered](itr CoreIterator[T]) opt.Val[T] {
+	return MaxBy(itr, cmp.Compare[T])
+}
+
+// Sum returns the sum of the elements of a numeric iterator, which is zero if
+// the iterator is empty. If the iterator is known to be of infinite size, this
+// function will panic with [ErrSizeInfinite].
+func Sum[T functions.Numeric](itr CoreIterator[T]) T {
+	return Fold(itr, 0, functions.Sum[T])
+}
+
+// Product returns the product of the elements of a numeric iterator, which is
+// one if the iterator is empty. If the iterator is known to be of infinite
+// size, this function will panic with [ErrSizeInfinite].
+func Product[T functions.Numeric](itr CoreIterator[T]) T {
+	return Fold(itr, 1, functions.Product[T])
+}
+
+// Count consumes an iterator and returns the number of elements it produced.
+// If the iterator is known to be of infinite size, this function will panic
+// with [ErrSizeInfinite].
+func Count[T any](itr CoreIterator[T]) int {
+	return Fold(itr, 0, func(n int, _ T) int { return n + 1 })
+}
+
+// Average returns the arithmetic mean of the elements of a numeric iterator.
+// If the iterator is empty, an empty value is returned. If the iterator is
+// known to be of infinite size, this function will panic with
+// [ErrSizeInfinite].
+func Average[T ordered.Real](itr CoreIterator[T]) opt.Val[float64] {
+	if stats, ok := Stats(itr).GetOK(); ok {
+		return opt.Value(stats.Mean)
+	}
+	return opt.Empty[float64]()
+}
+
+// Stats computes [Statistics] for the elements of a numeric iterator in a
+// single pass, using Welford's algorithm for the mean and variance. If the
+// iterator is empty, an empty value is returned. If the iterator is known to
+// be of infinite size, this function will panic with [ErrSizeInfinite].
+func Stats[T ordered.Real](itr CoreIterator[T]) opt.Val[Statistics[T]] {
+	var m2 float64
+	stats := Fold(itr, Statistics[T]{}, func(s Statistics[T], e T) Statistics[T] {
+		if s.Count == 0 || e < s.Min {
+			s.Min = e
+		}
+		if s.Count == 0 || e > s.Max {
+			s.Max = e
+		}
+		s.Count++
+		x := float64(e)
+		s.Sum += x
+		delta := x - s.Mean
+		s.Mean += delta / float64(s.Count)
+		m2 += delta * (x - s.Mean)
+		return s
+	})
+	if stats.Count == 0 {
+		return opt.Empty[Statistics[T]]()
+	}
+	stats.Variance = m2 / float64(stats.Count)
+	return opt.Value(stats)
+}
@@ -0,0 +1,300 @@
+package iterator
+
+import (
+	"fmt"
+	"iter"
+)
+
+// repeatIter produces the same value a number of times, or indefinitely if
+// count is negative.
+type repeatIter[T any] struct {
+	value     T
+	count     int
+	remaining int
+	done      bool
+}
+
+func (ri *repeatIter[T]) infinite() bool {
+	return ri.count < 0
+}
+
+func (ri *repeatIter[T]) Next() bool {
+	if ri.done {
+		return false
+	}
+	if !ri.infinite() {
+		if ri.remaining == 0 {
+			ri.done = true
+			return false
+		}
+		ri.remaining--
+	}
+	return true
+}
+
+func (ri *repeatIter[T]) Value() T {
+	return ri.value
+}
+
+func (ri *repeatIter[T]) Abort() {
+	ri.done = true
+}
+
+func (ri *repeatIter[T]) Reset() {
+	ri.remaining = ri.count
+	ri.done = false
+}
+
+func (ri *repeatIter[T]) Size() IteratorSize {
+	switch {
+	case ri.done:
+		return NewSize(0)
+	case ri.infinite():
+		return NewSizeInfinite()
+	default:
+		return NewSize(ri.remaining)
+	}
+}
+
+func (ri *repeatIter[T]) SeqOK() bool { return true }
+
+func (ri *repeatIter[T]) Seq() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for ri.Next() {
+			if !yield(ri.value) {
+				ri.Abort()
+				return
+			}
+		}
+	}
+}
+
+// cycleIter produces the elements of an iterator, retaining them as they are
+// read, and then replays them from the cache endlessly.
+type cycleIter[T any] struct {
+	base      CoreIterator[T]
+	cache     []T
+	replaying bool
+	pos       int
+	value     T
+	done      bool
+}
+
+func (ci *cycleIter[T]) Next() bool {
+	if ci.done {
+		return false
+	}
+	if !ci.replaying {
+		if ci.base.Next() {
+			ci.value = ci.base.Value()
+			ci.cache = append(ci.cache, ci.value)
+			return true
+		}
+		ci.replaying = true
+		if len(ci.cache) == 0 {
+			ci.done = true
+			return false
+		}
+	}
+	ci.value = ci.cache[ci.pos]
+	ci.pos = (ci.pos + 1) % len(ci.cache)
+	return true
+}
+
+func (ci *cycleIter[T]) Value() T {
+	return ci.value
+}
+
+func (ci *cycleIter[T]) Abort() {
+	if !ci.done {
+		ci.done = true
+		if !ci.replaying {
+			ci.base.Abort()
+		}
+	}
+}
+
+func (ci *cycleIter[T]) Reset() {
+	ci.base.Reset()
+	ci.cache = nil
+	ci.replaying = false
+	ci.pos = 0
+	ci.done = false
+}
+
+// Size is infinite once any element has been seen, or if the underlying
+// iterator is known to be non-empty. Otherwise it is not known whether the
+// underlying iterator will produce anything to cycle over.
+func (ci *cycleIter[T]) Size() IteratorSize {
+	if ci.done {
+		return NewSize(0)
+	}
+	if len(ci.cache) > 0 {
+		return NewSizeInfinite()
+	}
+	size := ci.base.Size()
+	switch {
+	case size.IsInfinite() || (size.IsKnown() && size.Size > 0):
+		return NewSizeInfinite()
+	case size.IsKnownToBe(0) || size.IsMaxKnownToBe(0):
+		return NewSize(0)
+	default:
+		return NewSizeUnknown()
+	}
+}
+
+func (ci *cycleIter[T]) SeqOK() bool { return false }
+
+func (ci *cycleIter[T]) Seq() iter.Seq[T] {
+	return Seq(ci)
+}
+
+// unfoldIter produces elements from a state, which is advanced by a step
+// function until it reports that there are no more elements.
+type unfoldIter[T any, S any] struct {
+	seed  S
+	state S
+	step  func(S) (T, S, bool)
+	value T
+	done  bool
+}
+
+func (ui *unfoldIter[T, S]) Next() bool {
+	if ui.done {
+		return false
+	}
+	value, state, ok := ui.step(ui.state)
+	if !ok {
+		ui.done = true
+		return false
+	}
+	ui.value, ui.state = value, state
+	return true
+}
+
+func (ui *unfoldIter[T, S]) Value() T {
+	return ui.value
+}
+
+func (ui *unfoldIter[T, S]) Abort() {
+	ui.done = true
+}
+
+func (ui *unfoldIter[T, S]) Reset() {
+	ui.state = ui.seed
+	ui.done = false
+}
+
+func (ui *unfoldIter[T, S]) Size() IteratorSize {
+	if ui.done {
+		return NewSize(0)
+	}
+	return NewSizeUnknown()
+}
+
+func (ui *unfoldIter[T, S]) SeqOK() bool { return false }
+
+func (ui *unfoldIter[T, S]) Seq() iter.Seq[T] {
+	return Seq(ui)
+}
+
+// iterateIter produces a seed value followed by the result of repeatedly
+// applying a function to it.
+type iterateIter[T any] struct {
+	seed    T
+	f       func(T) T
+	value   T
+	started bool
+	done    bool
+}
+
+func (ii *iterateIter[T]) Next() bool {
+	if ii.done {
+		return false
+	}
+	if ii.started {
+		ii.value = ii.f(ii.value)
+	} else {
+		ii.value = ii.seed
+		ii.started = true
+	}
+	return true
+}
+
+func (ii *iterateIter[T]) Value() T {
+	return ii.value
+}
+
+func (ii *iterateIter[T]) Abort() {
+	ii.done = true
+}
+
+func (ii *iterateIter[T]) Reset() {
+	ii.started = false
+	ii.done = false
+}
+
+func (ii *iterateIter[T]) Size() IteratorSize {
+	if ii.done {
+		return NewSize(0)
+	}
+	return NewSizeInfinite()
+}
+
+func (ii *iterateIter[T]) SeqOK() bool { return false }
+
+func (ii *iterateIter[T]) Seq() iter.Seq[T] {
+	return Seq(ii)
+}
+
+// Repeat produces an iterator that yields the value v endlessly. The iterator
+// has an infinite size, so it must be limited, e.g. with [Take], before being
+// consumed by a function such as [Collect]. E.g.
+//
+//	itr := iterator.Repeat("a").Take(3)
+//	result := itr.Collect() // []string{"a","a","a"}
+func Repeat[T any](v T) Iterator[T] {
+	return NewDefaultIterator(&repeatIter[T]{value: v, count: -1, remaining: -1})
+}
+
+// RepeatN produces an iterator that yields the value v exactly n times. This
+// function will panic with [ErrInvalidIteratorRange] if n is negative.
+func RepeatN[T any](v T, n int) Iterator[T] {
+	if n < 0 {
+		panic(fmt.Errorf("%w: repeat count %d is negative", ErrInvalidIteratorRange, n))
+	}
+	return NewDefaultIterator(&repeatIter[T]{value: v, count: n, remaining: n})
+}
+
+// Cycle produces an iterator that yields the elements of itr, and then yields
+// them again endlessly. The elements are retained in memory as they are read
+// during the first pass, so that the underlying iterator is only consumed
+// once. If itr is empty, so is the resulting iterator. Otherwise, it has an
+// infinite size, so it must be limited before being consumed by a function
+// such as [Collect]. E.g.
+//
+//	itr := iterator.Cycle(iterator.Of(1, 2, 3)).Take(7)
+//	result := itr.Collect() // []int{1,2,3,1,2,3,1}
+func Cycle[T any](itr CoreIterator[T]) Iterator[T] {
+	return NewDefaultIterator(&cycleIter[T]{base: itr})
+}
+
+// Iterate produces an infinite iterator that yields seed, then f(seed), then
+// f(f(seed)) and so on. E.g.
+//
+//	itr := iterator.Iterate(1, func(n int) int { return n * 2 }).Take(5)
+//	result := itr.Collect() // []int{1,2,4,8,16}
+func Iterate[T any](seed T, f func(T) T) Iterator[T] {
+	return NewDefaultIterator(&iterateIter[T]{seed: seed, f: f})
+}
+
+// Unfold produces an iterator from a state, starting with seed. On each
+// iteration, the function f is called with the current state, and returns an
+// element to produce, the next state and true, or false if there are no more
+// elements. The size of the resulting iterator is unknown. E.g.
+//
+//	digits := iterator.Unfold(1234, func(n int) (int, int, bool) { return n % 10, n / 10, n > 0 })
+//	result := digits.Collect() // []int{4,3,2,1}
+func Unfold[T any, S any](seed S, f func(S) (T, S, bool)) Iterator[T] {
+	return NewDefaultIterator(&unfoldIter[T, S]{seed: seed, state: seed, step: f})
+}
@@ -0,0 +1,175 @@
+package iterator
+
+import "iter"
+
+// DefaultDoubleEndedIterator wraps a [CoreDoubleEndedIterator] together with a
+// [DefaultIterator] to provide an implementation of [DoubleEndedIterator].
+type DefaultDoubleEndedIterator[T any] struct {
+	CoreDoubleEndedIterator[T]
+	DefaultIterator[T]
+}
+
+// NewDefaultDoubleEndedIterator builds a [DoubleEndedIterator] from a
+// [CoreDoubleEndedIterator] by adding the methods of [IteratorExtensions].
+func NewDefaultDoubleEndedIterator[T any](citr CoreDoubleEndedIterator[T]) DefaultDoubleEndedIterator[T] {
+	return DefaultDoubleEndedIterator[T]{CoreDoubleEndedIterator: citr, DefaultIterator: DefaultIterator[T]{CoreIterator: citr}}
+}
+
+// DefaultDoubleEndedIterator2 wraps a [CoreDoubleEndedIterator2] together with
+// a [DefaultIterator2] to provide an implementation of [DoubleEndedIterator2].
+type DefaultDoubleEndedIterator2[K any, V any] struct {
+	CoreDoubleEndedIterator2[K, V]
+	DefaultIterator2[K, V]
+}
+
+// NewDefaultDoubleEndedIterator2 builds a [DoubleEndedIterator2] from a
+// [CoreDoubleEndedIterator2] by adding the methods of [IteratorExtensions] and
+// [Iterator2Extensions].
+func NewDefaultDoubleEndedIterator2[K any, V any](citr CoreDoubleEndedIterator2[K, V]) DefaultDoubleEndedIterator2[K, V] {
+	return DefaultDoubleEndedIterator2[K, V]{CoreDoubleEndedIterator2: citr, DefaultIterator2: NewDefaultIterator2(citr)}
+}
+
+// asDoubleEnded returns the double ended core of an iterator, looking inside
+// the default wrapper types, if there is one.
+func asDoubleEnded[T any](itr CoreIterator[T]) (CoreDoubleEndedIterator[T], bool) {
+	switch it := itr.(type) {
+	case DefaultDoubleEndedIterator[T]:
+		return it.CoreDoubleEndedIterator, true
+	case CoreDoubleEndedIterator[T]:
+		return it, true
+	case DefaultIterator[T]:
+		return asDoubleEnded(it.CoreIterator)
+	case DefaultMutableIterator[T]:
+		return asDoubleEnded[T](it.CoreMutableIterator)
+	}
+	return nil, false
+}
+
+// revIter swaps the ends of a double ended iterator.
+type revIter[T any] struct {
+	base CoreDoubleEndedIterator[T]
+}
+
+func (ri *revIter[T]) Next() bool         { return ri.base.NextBack() }
+func (ri *revIter[T]) NextBack() bool     { return ri.base.Next() }
+func (ri *revIter[T]) Value() T           { return ri.base.Value() }
+func (ri *revIter[T]) Abort()             { ri.base.Abort() }
+func (ri *revIter[T]) Reset()             { ri.base.Reset() }
+func (ri *revIter[T]) Size() IteratorSize { return ri.base.Size() }
+func (ri *revIter[T]) SeqOK() bool        { return false }
+func (ri *revIter[T]) Seq() iter.Seq[T]   { return Seq(ri) }
+
+// revIter2 is the [CoreIterator2] equivalent of revIter.
+type revIter2[K any, V any] struct {
+	base CoreDoubleEndedIterator2[K, V]
+}
+
+func (ri *revIter2[K, V]) Next() bool         { return ri.base.NextBack() }
+func (ri *revIter2[K, V]) NextBack() bool     { return ri.base.Next() }
+func (ri *revIter2[K, V]) Key() K             { return ri.base.Key() }
+func (ri *revIter2[K, V]) Value() V           { return ri.base.Value() }
+func (ri *revIter2[K, V]) Abort()             { ri.base.Abort() }
+func (ri *revIter2[K, V]) Reset()             { ri.base.Reset() }
+func (ri *revIter2[K, V]) Size() IteratorSize { return ri.base.Size() }
+func (ri *revIter2[K, V]) SeqOK() bool        { return false }
+func (ri *revIter2[K, V]) Seq() iter.Seq[V]   { return Seq(ri) }
+
+func (ri *revIter2[K, V]) Seq2() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for ri.Next() {
+			if !yield(ri.Key(), ri.Value()) {
+				ri.Abort()
+				break
+			}
+		}
+	}
+}
+
+// bufferedIter makes an iterator double ended by collecting its elements
+// into a slice when they are first requested from either end.
+type bufferedIter[T any] struct {
+	source    CoreIterator[T]
+	elements  []T
+	slice     sliceIter[T]
+	collected bool
+}
+
+func (bi *bufferedIter[T]) collect() {
+	if !bi.collected {
+		bi.elements = Collect(bi.source)
+		bi.slice = sliceIter[T]{slice: &bi.elements}
+		bi.collected = true
+	}
+}
+
+func (bi *bufferedIter[T]) Next() bool {
+	bi.collect()
+	return bi.slice.Next()
+}
+
+func (bi *bufferedIter[T]) NextBack() bool {
+	bi.collect()
+	return bi.slice.NextBack()
+}
+
+func (bi *bufferedIter[T]) Value() T {
+	return bi.slice.Value()
+}
+
+func (bi *bufferedIter[T]) Abort() {
+	if !bi.collected {
+		bi.source.Abort()
+		bi.slice = sliceIter[T]{slice: &bi.elements}
+		bi.collected = true
+	}
+	bi.slice.Abort()
+}
+
+func (bi *bufferedIter[T]) Reset() {
+	bi.source.Reset()
+	bi.elements = nil
+	bi.collected = false
+}
+
+func (bi *bufferedIter[T]) Size() IteratorSize {
+	if bi.collected {
+		return bi.slice.Size()
+	}
+	return bi.source.Size()
+}
+
+func (bi *bufferedIter[T]) SeqOK() bool      { return false }
+func (bi *bufferedIter[T]) Seq() iter.Seq[T] { return Seq(bi) }
+
+// Rev produces an iterator over the elements of itr in reverse order. If itr
+// is a [DoubleEndedIterator], or is built on a [CoreDoubleEndedIterator], the
+// elements are consumed from its back without any buffering. Otherwise, all
+// the elements of itr are collected into memory when the first element is
+// requested, so this function will panic with [ErrSizeInfinite] at that point
+// if itr is known to be infinite. The resulting iterator is itself double
+// ended; consuming it from the back consumes itr from the front. E.g.
+//
+//	itr := iterator.Rev(iterator.Range(0, 5))
+//	result := itr.Collect() // []int{4,3,2,1,0}
+func Rev[T any](itr CoreIterator[T]) DoubleEndedIterator[T] {
+	if de, ok := asDoubleEnded(itr); ok {
+		if ri, ok := de.(*revIter[T]); ok {
+			return NewDefaultDoubleEndedIterator(ri.base)
+		}
+		return NewDefaultDoubleEndedIterator[T](&revIter[T]{base: de})
+	}
+	return NewDefaultDoubleEndedIterator[T](&revIter[T]{base: &bufferedIter[T]{source: itr}})
+}
+
+// Rev2 produces an iterator over the key and value pairs of a double ended
+// iterator in reverse order. Consuming the resulting iterator from the back
+// consumes itr from the front.
+func Rev2[K any, V any](itr CoreDoubleEndedIterator2[K, V]) DoubleEndedIterator2[K, V] {
+	if de, ok := itr.(DefaultDoubleEndedIterator2[K, V]); ok {
+		itr = de.CoreDoubleEndedIterator2
+	}
+	if ri, ok := itr.(*revIter2[K, V]); ok {
+		return NewDefaultDoubleEndedIterator2(ri.base)
+	}
+	return NewDefaultDoubleEndedIterator2[K, V](&revIter2[K, V]{base: itr})
+}
@@ -0,0 +1,165 @@
+package iterator
+
+import (
+	"iter"
+
+	"github.com/robdavid/genutil-go/functions"
+)
+
+type scanIter[T any, U any] struct {
+	base    CoreIterator[T]
+	f       func(a U, e T) U
+	init    U
+	acc     U
+	pending bool
+}
+
+func (si *scanIter[T, U]) Next() bool {
+	if si.pending {
+		si.pending = false
+		si.acc = si.init
+		return true
+	}
+	if si.base.Next() {
+		si.acc = si.f(si.acc, si.base.Value())
+		return true
+	}
+	return false
+}
+
+func (si *scanIter[T, U]) Value() U {
+	return si.acc
+}
+
+func (si *scanIter[T, U]) Abort() {
+	si.pending = false
+	si.base.Abort()
+}
+
+func (si *scanIter[T, U]) Reset() {
+	si.pending = true
+	si.base.Reset()
+}
+
+// Size is one more than the size of the underlying iterator until the initial
+// value has been produced.
+func (si *scanIter[T, U]) Size() IteratorSize {
+	if si.pending {
+		return addSize(NewSize(1), si.base.Size())
+	}
+	return si.base.Size()
+}
+
+func (si *scanIter[T, U]) SeqOK() bool { return si.base.SeqOK() }
+
+func (si *scanIter[T, U]) Seq() iter.Seq[U] {
+	return func(yield func(U) bool) {
+		if si.pending {
+			si.pending = false
+			si.acc = si.init
+			if !yield(si.acc) {
+				return
+			}
+		}
+		for v := range si.base.Seq() {
+			si.acc = si.f(si.acc, v)
+			if !yield(si.acc) {
+				return
+			}
+		}
+	}
+}
+
+type scan1Iter[T any] struct {
+	base    CoreIterator[T]
+	f       func(a, e T) T
+	acc     T
+	started bool
+}
+
+func (si *scan1Iter[T]) accumulate(v T) {
+	if si.started {
+		si.acc = si.f(si.acc, v)
+	} else {
+		si.acc = v
+		si.started = true
+	}
+}
+
+func (si *scan1Iter[T]) Next() bool {
+	if si.base.Next() {
+		si.accumulate(si.base.Value())
+		return true
+	}
+	return false
+}
+
+func (si *scan1Iter[T]) Value() T {
+	return si.acc
+}
+
+func (si *scan1Iter[T]) Abort() {
+	si.base.Abort()
+}
+
+func (si *scan1Iter[T]) Reset() {
+	si.started = false
+	si.base.Reset()
+}
+
+func (si *scan1Iter[T]) Size() IteratorSize {
+	return si.base.Size()
+}
+
+func (si *scan1Iter[T]) SeqOK() bool { return si.base.SeqOK() }
+
+func (si *scan1Iter[T]) Seq() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range si.base.Seq() {
+			si.accumulate(v)
+			if !yield(si.acc) {
+				return
+			}
+		}
+	}
+}
+
+// Scan is a variation on [Fold] which produces an iterator over every
+// intermediate value of the accumulator, rather than only the final one. The
+// first element produced is the initial value init, and each subsequent
+// element is the result of applying the accumulation function f to the
+// previous element and the next element of the input iterator. The resulting
+// iterator therefore has one more element than the input. E.g.
+//
+//	itr := iterator.Scan(iterator.Of(1, 2, 3), 10, functions.Sum)
+//	result := itr.Collect() // []int{10,11,13,16}
+func Scan[T any, U any](itr CoreIterator[T], init U, f func(a U, e T) U) Iterator[U] {
+	return NewDefaultIterator(&scanIter[T, U]{base: itr, f: f, init: init, pending: true})
+}
+
+// Scan1 is a variation on [Fold1] which produces an iterator over every
+// intermediate value of the accumulator, rather than only the final one. The
+// first element produced is the first element of the input iterator, and each
+// subsequent element is the result of applying the accumulation function f to
+// the previous element and the next element of the input iterator. The
+// resulting iterator has the same number of elements as the input. E.g.
+//
+//	itr := iterator.Scan1(iterator.Of(1, 2, 3), functions.Sum)
+//	result := itr.Collect() // []int{1,3,6}
+func Scan1[T any](itr CoreIterator[T], f func(a, e T) T) Iterator[T] {
+	return NewDefaultIterator(&scan1Iter[T]{base: itr, f: f})
+}
+
+// PrefixSum produces an iterator over the running totals of the elements of a
+// numeric iterator. Each element produced is the sum of all the input elements
+// up to and including the corresponding one.
+func PrefixSum[T functions.Numeric](itr CoreIterator[T]) Iterator[T] {
+	return Scan1(itr, functions.Sum[T])
+}
+
+// PrefixProduct produces an iterator over the running products of the
+// elements of a numeric iterator. Each element produced is the product of all
+// the input elements up to and including the corresponding one.
+func PrefixProduct[T functions.Numeric](itr CoreIterator[T]) Iterator[T] {
+	return Scan1(itr, functions.Product[T])
+}
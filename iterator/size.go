@@ -109,3 +109,54 @@ func NewSizeInfinite() IteratorSize {
 func (size IteratorSize) IsInfinite() bool {
 	return size.Type == SizeInfinite
 }
+
+// minSize returns the size of an iterator that yields as many elements as the
+// smaller of two iterators of sizes a and b.
+func minSize(a, b IteratorSize) IteratorSize {
+	switch {
+	case a.IsInfinite():
+		return b
+	case b.IsInfinite():
+		return a
+	case a.IsKnown() && b.IsKnown():
+		return NewSize(min(a.Size, b.Size))
+	case a.IsUnknown() && b.IsUnknown():
+		return NewSizeUnknown()
+	case a.IsUnknown():
+		return NewSizeMax(b.Size)
+	case b.IsUnknown():
+		return NewSizeMax(a.Size)
+	default:
+		return NewSizeMax(min(a.Size, b.Size))
+	}
+}
+
+// maxSize returns the size of an iterator that yields as many elements as the
+// larger of two iterators of sizes a and b.
+func maxSize(a, b IteratorSize) IteratorSize {
+	switch {
+	case a.IsInfinite() || b.IsInfinite():
+		return NewSizeInfinite()
+	case a.IsUnknown() || b.IsUnknown():
+		return NewSizeUnknown()
+	case a.IsKnown() && b.IsKnown():
+		return NewSize(max(a.Size, b.Size))
+	default:
+		return NewSizeMax(max(a.Size, b.Size))
+	}
+}
+
+// addSize returns the size of an iterator that yields all the elements of two
+// iterators of sizes a and b.
+func addSize(a, b IteratorSize) IteratorSize {
+	switch {
+	case a.IsInfinite() || b.IsInfinite():
+		return NewSizeInfinite()
+	case a.IsUnknown() || b.IsUnknown():
+		return NewSizeUnknown()
+	case a.IsKnown() && b.IsKnown():
+		return NewSize(a.Size + b.Size)
+	default:
+		return NewSizeMax(a.Size + b.Size)
+	}
+}
@@ -0,0 +1,345 @@
+package iterator
+
+import (
+	"fmt"
+	"iter"
+)
+
+type skipIterator[T any] struct {
+	n, skip  int
+	iterator CoreIterator[T]
+}
+
+func (si *skipIterator[T]) Value() T {
+	return si.iterator.Value()
+}
+
+func (si *skipIterator[T]) Abort() {
+	si.skip = 0
+	si.iterator.Abort()
+}
+
+func (si *skipIterator[T]) Reset() {
+	si.skip = si.n
+	si.iterator.Reset()
+}
+
+func (si *skipIterator[T]) Next() bool {
+	for ; si.skip > 0; si.skip-- {
+		if !si.iterator.Next() {
+			si.skip = 0
+			return false
+		}
+	}
+	return si.iterator.Next()
+}
+
+func (si *skipIterator[T]) SeqOK() bool { return si.iterator.SeqOK() }
+
+func (si *skipIterator[T]) Seq() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for value := range si.iterator.Seq() {
+			if si.skip > 0 {
+				si.skip--
+			} else if !yield(value) {
+				return
+			}
+		}
+		si.skip = 0
+	}
+}
+
+func (si *skipIterator[T]) Size() IteratorSize {
+	itrSize := si.iterator.Size()
+	switch itrSize.Type {
+	case SizeKnown:
+		return NewSize(max(0, itrSize.Size-si.skip))
+	case SizeAtMost:
+		return NewSizeMax(max(0, itrSize.Size-si.skip))
+	default:
+		return itrSize
+	}
+}
+
+type skipIterator2[K any, V any] struct {
+	skipIterator[V]
+	iterator2 CoreIterator2[K, V]
+}
+
+func (si2 *skipIterator2[K, V]) Key() K {
+	return si2.iterator2.Key()
+}
+
+func (si2 *skipIterator2[K, V]) Seq2() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for key, value := range si2.iterator2.Seq2() {
+			if si2.skip > 0 {
+				si2.skip--
+			} else if !yield(key, value) {
+				return
+			}
+		}
+		si2.skip = 0
+	}
+}
+
+type skipWhileIterator[T any] struct {
+	predicate func(T) bool
+	skipping  bool
+	iterator  CoreIterator[T]
+}
+
+func (sw *skipWhileIterator[T]) Value() T {
+	return sw.iterator.Value()
+}
+
+func (sw *skipWhileIterator[T]) Abort() {
+	sw.skipping = false
+	sw.iterator.Abort()
+}
+
+func (sw *skipWhileIterator[T]) Reset() {
+	sw.skipping = true
+	sw.iterator.Reset()
+}
+
+func (sw *skipWhileIterator[T]) Next() bool {
+	if sw.skipping {
+		sw.skipping = false
+		for sw.iterator.Next() {
+			if !sw.predicate(sw.iterator.Value()) {
+				return true
+			}
+		}
+		return false
+	}
+	return sw.iterator.Next()
+}
+
+func (sw *skipWhileIterator[T]) SeqOK() bool { return sw.iterator.SeqOK() }
+
+func (sw *skipWhileIterator[T]) Seq() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for value := range sw.iterator.Seq() {
+			if sw.skipping {
+				if sw.predicate(value) {
+					continue
+				}
+				sw.skipping = false
+			}
+			if !yield(value) {
+				return
+			}
+		}
+		sw.skipping = false
+	}
+}
+
+func (sw *skipWhileIterator[T]) Size() IteratorSize {
+	if sw.skipping {
+		return sw.iterator.Size().Subset()
+	}
+	return sw.iterator.Size()
+}
+
+type skipWhileIterator2[K any, V any] struct {
+	skipWhileIterator[V]
+	predicate2 func(K, V) bool
+	iterator2  CoreIterator2[K, V]
+}
+
+func (sw2 *skipWhileIterator2[K, V]) Key() K {
+	return sw2.iterator2.Key()
+}
+
+func (sw2 *skipWhileIterator2[K, V]) Seq() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, value := range sw2.Seq2() {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+func (sw2 *skipWhileIterator2[K, V]) Seq2() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for key, value := range sw2.iterator2.Seq2() {
+			if sw2.skipping {
+				if sw2.predicate2(key, value) {
+					continue
+				}
+				sw2.skipping = false
+			}
+			if !yield(key, value) {
+				return
+			}
+		}
+		sw2.skipping = false
+	}
+}
+
+type stepByIterator[T any] struct {
+	step, pending int
+	iterator      CoreIterator[T]
+}
+
+func (sb *stepByIterator[T]) Value() T {
+	return sb.iterator.Value()
+}
+
+func (sb *stepByIterator[T]) Abort() {
+	sb.iterator.Abort()
+}
+
+func (sb *stepByIterator[T]) Reset() {
+	sb.pending = 0
+	sb.iterator.Reset()
+}
+
+func (sb *stepByIterator[T]) Next() bool {
+	for ; sb.pending > 0; sb.pending-- {
+		if !sb.iterator.Next() {
+			return false
+		}
+	}
+	if !sb.iterator.Next() {
+		return false
+	}
+	sb.pending = sb.step - 1
+	return true
+}
+
+func (sb *stepByIterator[T]) SeqOK() bool { return sb.iterator.SeqOK() }
+
+func (sb *stepByIterator[T]) Seq() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for value := range sb.iterator.Seq() {
+			if sb.pending > 0 {
+				sb.pending--
+				continue
+			}
+			sb.pending = sb.step - 1
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// steps returns the number of elements that will be produced from the given
+// number of remaining elements of the underlying iterator.
+func (sb *stepByIterator[T]) steps(size int) int {
+	if size <= sb.pending {
+		return 0
+	}
+	return ceilDiv(size-sb.pending, sb.step)
+}
+
+func (sb *stepByIterator[T]) Size() IteratorSize {
+	itrSize := sb.iterator.Size()
+	switch itrSize.Type {
+	case SizeKnown:
+		return NewSize(sb.steps(itrSize.Size))
+	case SizeAtMost:
+		return NewSizeMax(sb.steps(itrSize.Size))
+	default:
+		return itrSize
+	}
+}
+
+type stepByIterator2[K any, V any] struct {
+	stepByIterator[V]
+	iterator2 CoreIterator2[K, V]
+}
+
+func (sb2 *stepByIterator2[K, V]) Key() K {
+	return sb2.iterator2.Key()
+}
+
+func (sb2 *stepByIterator2[K, V]) Seq2() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for key, value := range sb2.iterator2.Seq2() {
+			if sb2.pending > 0 {
+				sb2.pending--
+				continue
+			}
+			sb2.pending = sb2.step - 1
+			if !yield(key, value) {
+				return
+			}
+		}
+	}
+}
+
+// Skip transforms a [CoreIterator] into an [Iterator] that discards the first
+// n elements of the original iterator and returns the rest. If the original
+// iterator has n elements or fewer, the returned iterator is empty.
+func Skip[T any](n int, iter CoreIterator[T]) Iterator[T] {
+	n = max(n, 0)
+	if ra, ok := asRandomAccess(iter); ok {
+		return NewDefaultRandomAccessIterator[T](&skipRandomIter[T]{source: ra, n: n, skip: n})
+	}
+	return NewDefaultIterator(&skipIterator[T]{iterator: iter, n: n, skip: n})
+}
+
+// Skip2 transforms a [CoreIterator2] into an [Iterator2] that discards the
+// first n pairs of elements of the original iterator and returns the rest. If
+// the original iterator has n pairs or fewer, the returned iterator is empty.
+func Skip2[K any, V any](n int, iter CoreIterator2[K, V]) Iterator2[K, V] {
+	n = max(n, 0)
+	return NewDefaultIterator2(&skipIterator2[K, V]{skipIterator: skipIterator[V]{iterator: iter, n: n, skip: n}, iterator2: iter})
+}
+
+// SkipWhile transforms a [CoreIterator] into an [Iterator] that discards
+// elements of the original iterator for as long as they satisfy the predicate
+// function p. The first element that fails to satisfy p, and all elements
+// after it, are returned. E.g.
+//
+//	itr := iterator.SkipWhile(iterator.Of(1, 2, 5, 1), func(n int) bool { return n < 3 })
+//	result := itr.Collect() // []int{5,1}
+func SkipWhile[T any](iter CoreIterator[T], p func(T) bool) Iterator[T] {
+	return NewDefaultIterator(&skipWhileIterator[T]{iterator: iter, predicate: p, skipping: true})
+}
+
+// SkipWhile2 transforms a [CoreIterator2] into an [Iterator2] that discards
+// pairs of elements of the original iterator for as long as they satisfy the
+// predicate function p. The first pair that fails to satisfy p, and all pairs
+// after it, are returned.
+func SkipWhile2[K any, V any](iter CoreIterator2[K, V], p func(K, V) bool) Iterator2[K, V] {
+	sw2 := &skipWhileIterator2[K, V]{predicate2: p, iterator2: iter}
+	sw2.skipWhileIterator = skipWhileIterator[V]{
+		iterator:  iter,
+		predicate: func(v V) bool { return p(iter.Key(), v) },
+		skipping:  true,
+	}
+	return NewDefaultIterator2(sw2)
+}
+
+// StepBy transforms a [CoreIterator] into an [Iterator] that returns the first
+// element of the original iterator, and then every step'th element after that.
+// If step is less than 1, this function will panic with
+// [ErrInvalidIteratorRange]. E.g.
+//
+//	itr := iterator.StepBy(3, iterator.Range(0, 10))
+//	result := itr.Collect() // []int{0,3,6,9}
+func StepBy[T any](step int, iter CoreIterator[T]) Iterator[T] {
+	if step < 1 {
+		panic(fmt.Errorf("%w: step %d is less than 1", ErrInvalidIteratorRange, step))
+	}
+	if ra, ok := asRandomAccess(iter); ok {
+		return NewDefaultRandomAccessIterator[T](&stepByRandomIter[T]{source: ra, step: step})
+	}
+	return NewDefaultIterator(&stepByIterator[T]{iterator: iter, step: step})
+}
+
+// StepBy2 transforms a [CoreIterator2] into an [Iterator2] that returns the
+// first pair of elements of the original iterator, and then every step'th pair
+// after that. If step is less than 1, this function will panic with
+// [ErrInvalidIteratorRange].
+func StepBy2[K any, V any](step int, iter CoreIterator2[K, V]) Iterator2[K, V] {
+	if step < 1 {
+		panic(fmt.Errorf("%w: step %d is less than 1", ErrInvalidIteratorRange, step))
+	}
+	return NewDefaultIterator2(&stepByIterator2[K, V]{stepByIterator: stepByIterator[V]{iterator: iter, step: step}, iterator2: iter})
+}
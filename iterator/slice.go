@@ -4,21 +4,38 @@ import "iter"
 
 // Iterator over a slice
 type sliceIter[T any] struct {
-	slice *[]T
-	index int
-	ref   *T
+	slice    *[]T
+	index    int
+	back     int // number of elements consumed from the back
+	fromBack bool
+	ref      *T
+}
+
+func (si *sliceIter[T]) end() int {
+	return len(*si.slice) - si.back
 }
 
 func (si *sliceIter[T]) Next() bool {
-	if si.index < len(*si.slice) {
+	if si.index < si.end() {
 		si.ref = &(*si.slice)[si.index]
 		si.index++
+		si.fromBack = false
 		return true
 	} else {
 		return false
 	}
 }
 
+func (si *sliceIter[T]) NextBack() bool {
+	if end := si.end(); si.index < end {
+		si.ref = &(*si.slice)[end-1]
+		si.back++
+		si.fromBack = true
+		return true
+	}
+	return false
+}
+
 func (si *sliceIter[T]) Value() T {
 	if si.ref == nil {
 		var zero T
@@ -34,7 +51,17 @@ func (si *sliceIter[T]) Set(e T) {
 }
 
 func (si *sliceIter[T]) Delete() {
-	if si.index > len(*si.slice) || si.index < 1 || si.ref == nil {
+	if si.ref == nil {
+		return
+	}
+	if si.fromBack {
+		pos := si.end()
+		*si.slice = append((*si.slice)[:pos], (*si.slice)[pos+1:]...)
+		si.back--
+		si.ref = nil
+		return
+	}
+	if si.index > len(*si.slice) || si.index < 1 {
 		return
 	}
 	*si.slice = append((*si.slice)[:si.index-1], (*si.slice)[si.index:]...)
@@ -44,20 +71,48 @@ func (si *sliceIter[T]) Delete() {
 
 func (si *sliceIter[T]) Abort() {
 	si.index = len(*si.slice)
+	si.back = 0
 }
 
 func (si *sliceIter[T]) Reset() {
 	si.index = 0
+	si.back = 0
 }
 
 func (si *sliceIter[T]) Size() IteratorSize {
-	return NewSize(len(*si.slice) - si.index)
+	return NewSize(si.end() - si.index)
+}
+
+func (si *sliceIter[T]) Len() int {
+	return si.end() - si.index
+}
+
+func (si *sliceIter[T]) At(i int) T {
+	checkIndex(i, si.Len())
+	return (*si.slice)[si.index+i]
+}
+
+// Slice produces an iterator over a subslice of the remaining elements. The
+// subslice shares the same backing array, but is capped so that appending to
+// it cannot overwrite elements beyond it.
+func (si *sliceIter[T]) Slice(i, j int) RandomAccessIterator[T] {
+	checkSlice(i, j, si.Len())
+	sub := (*si.slice)[si.index+i : si.index+j : si.index+j]
+	return NewDefaultRandomAccessIterator[T](&sliceIter[T]{slice: &sub})
+}
+
+func (si *sliceIter[T]) advance(n int) {
+	si.index = min(si.index+n, si.end())
+}
+
+func (si *sliceIter[T]) TrySplit() (CoreIterator[T], bool) {
+	return splitHalf[T](si)
 }
 
 func (si *sliceIter[T]) Seq() iter.Seq[T] {
 	return func(yield func(T) bool) {
 		defer si.Abort()
-		for si.index = 0; si.index < len(*si.slice); {
+		for si.index = 0; si.index < si.end(); {
 			si.ref = &(*si.slice)[si.index]
 			si.index++
 			if !yield(*si.ref) {
@@ -67,7 +122,7 @@ func (si *sliceIter[T]) Seq() iter.Seq[T] {
 	}
 }
 
-func (si *sliceIter[T]) SeqOK() bool { return si.index == 0 }
+func (si *sliceIter[T]) SeqOK() bool { return si.index == 0 && si.back == 0 }
 
 func NewSliceCoreIterator[T any](slice *[]T) CoreMutableIterator[T] {
 	return &sliceIter[T]{slice: slice, index: 0}
@@ -88,7 +143,7 @@ func (sir *sliceIterRef[T]) Set(e *T) {
 func (sir *sliceIterRef[T]) Seq() iter.Seq[*T] {
 	return func(yield func(*T) bool) {
 		defer sir.Abort()
-		for sir.index = 0; sir.index < len(*sir.slice); {
+		for sir.index = 0; sir.index < sir.end(); {
 			sir.ref = &(*sir.slice)[sir.index]
 			sir.index++
 			if !yield(sir.ref) {
@@ -104,7 +159,7 @@ func (sir *sliceIterRef[T]) Seq() iter.Seq[*T] {
 // Deprecated: use slices.Iter()
 func Slice[T any](slice []T) Iterator[T] {
 	iter := &sliceIter[T]{slice: &slice, index: 0}
-	return NewDefaultIterator(iter)
+	return NewDefaultRandomAccessIterator[T](iter)
 }
 
 // MutSlice makes a MutableIterator[T] from slice []T, containing all the elements
@@ -105,6 +105,9 @@ func (ti *takeIterator2[K, V]) Seq2() iter.Seq2[K, V] {
 // [CoreIterator]. If there are less than or exactly n elements available, the
 // returned iterator is equivalent to the input iterator.
 func Take[T any](n int, iter CoreIterator[T]) Iterator[T] {
+	if ra, ok := asRandomAccess(iter); ok {
+		return NewDefaultRandomAccessIterator[T](&takeRandomIter[T]{source: ra, max: n})
+	}
 	return NewDefaultIterator(&takeIterator[T]{iterator: iter, max: n})
 }
 
@@ -115,3 +118,140 @@ func Take[T any](n int, iter CoreIterator[T]) Iterator[T] {
 func Take2[K any, V any](n int, iter CoreIterator2[K, V]) Iterator2[K, V] {
 	return NewDefaultIterator2(&takeIterator2[K, V]{takeIterator: takeIterator[V]{iterator: iter, max: n}, iterator2: iter})
 }
+
+type takeWhileIterator[T any] struct {
+	predicate func(T) bool
+	done      bool
+	iterator  CoreIterator[T]
+}
+
+func (tw *takeWhileIterator[T]) Value() T {
+	return tw.iterator.Value()
+}
+
+func (tw *takeWhileIterator[T]) Abort() {
+	if !tw.done {
+		tw.iterator.Abort()
+	}
+	tw.done = true
+}
+
+func (tw *takeWhileIterator[T]) Reset() {
+	tw.done = false
+	tw.iterator.Reset()
+}
+
+// stop is called when an element fails the predicate. The underlying iterator
+// is aborted, since no more of its elements are required.
+func (tw *takeWhileIterator[T]) stop() {
+	tw.iterator.Abort()
+	tw.done = true
+}
+
+func (tw *takeWhileIterator[T]) Next() bool {
+	if tw.done {
+		return false
+	}
+	if !tw.iterator.Next() {
+		tw.done = true
+		return false
+	}
+	if !tw.predicate(tw.iterator.Value()) {
+		tw.stop()
+		return false
+	}
+	return true
+}
+
+func (tw *takeWhileIterator[T]) SeqOK() bool { return !tw.done && tw.iterator.SeqOK() }
+
+func (tw *takeWhileIterator[T]) Seq() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if tw.done {
+			return
+		}
+		for value := range tw.iterator.Seq() {
+			if !tw.predicate(value) {
+				tw.done = true
+				return
+			}
+			if !yield(value) {
+				return
+			}
+		}
+		tw.done = true
+	}
+}
+
+func (tw *takeWhileIterator[T]) Size() IteratorSize {
+	if tw.done {
+		return NewSize(0)
+	}
+	itrSize := tw.iterator.Size()
+	if itrSize.IsInfinite() {
+		return NewSizeUnknown()
+	}
+	return itrSize.Subset()
+}
+
+type takeWhileIterator2[K any, V any] struct {
+	takeWhileIterator[V]
+	predicate2 func(K, V) bool
+	iterator2  CoreIterator2[K, V]
+}
+
+func (tw2 *takeWhileIterator2[K, V]) Key() K {
+	return tw2.iterator2.Key()
+}
+
+func (tw2 *takeWhileIterator2[K, V]) Seq() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, value := range tw2.Seq2() {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+func (tw2 *takeWhileIterator2[K, V]) Seq2() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if tw2.done {
+			return
+		}
+		for key, value := range tw2.iterator2.Seq2() {
+			if !tw2.predicate2(key, value) {
+				tw2.done = true
+				return
+			}
+			if !yield(key, value) {
+				return
+			}
+		}
+		tw2.done = true
+	}
+}
+
+// TakeWhile transforms a [CoreIterator] into an [Iterator] that returns
+// elements of the original iterator for as long as they satisfy the predicate
+// function p. Once an element fails to satisfy p, the returned iterator ends
+// and the original iterator is aborted; the failing element is discarded. E.g.
+//
+//	itr := iterator.TakeWhile(iterator.Range(0, 10), func(n int) bool { return n < 3 })
+//	result := itr.Collect() // []int{0,1,2}
+func TakeWhile[T any](iter CoreIterator[T], p func(T) bool) Iterator[T] {
+	return NewDefaultIterator(&takeWhileIterator[T]{iterator: iter, predicate: p})
+}
+
+// TakeWhile2 transforms a [CoreIterator2] into an [Iterator2] that returns
+// pairs of elements of the original iterator for as long as they satisfy the
+// predicate function p. Once a pair fails to satisfy p, the returned iterator
+// ends and the original iterator is aborted; the failing pair is discarded.
+func TakeWhile2[K any, V any](iter CoreIterator2[K, V], p func(K, V) bool) Iterator2[K, V] {
+	tw2 := &takeWhileIterator2[K, V]{predicate2: p, iterator2: iter}
+	tw2.takeWhileIterator = takeWhileIterator[V]{
+		iterator:  iter,
+		predicate: func(v V) bool { return p(iter.Key(), v) },
+	}
+	return NewDefaultIterator2(tw2)
+}
@@ -0,0 +1,251 @@
+package iterator
+
+import (
+	"fmt"
+	"iter"
+)
+
+// teeSource holds the state shared by the iterators produced by [Tee]. The
+// buffer holds the elements read from the underlying iterator that have not
+// yet been consumed by every active iterator; offset is the position in the
+// underlying iterator of the first of them.
+type teeSource[T any] struct {
+	base      CoreIterator[T]
+	buffer    []T
+	offset    int
+	positions []int
+	active    []bool
+	exhausted bool
+}
+
+// trim discards elements from the buffer that have been consumed by every
+// active iterator.
+func (ts *teeSource[T]) trim() {
+	lowest := ts.offset + len(ts.buffer)
+	for i, pos := range ts.positions {
+		if ts.active[i] {
+			lowest = min(lowest, pos)
+		}
+	}
+	clear(ts.buffer[:lowest-ts.offset])
+	ts.buffer = ts.buffer[lowest-ts.offset:]
+	ts.offset = lowest
+}
+
+func (ts *teeSource[T]) next(i int) (T, bool) {
+	var zero T
+	if !ts.active[i] {
+		return zero, false
+	}
+	index := ts.positions[i] - ts.offset
+	if index == len(ts.buffer) {
+		if ts.exhausted || !ts.base.Next() {
+			ts.exhausted = true
+			return zero, false
+		}
+		ts.buffer = append(ts.buffer, ts.base.Value())
+	}
+	value := ts.buffer[index]
+	ts.positions[i]++
+	if index == 0 {
+		ts.trim()
+	}
+	return value, true
+}
+
+func (ts *teeSource[T]) abort(i int) {
+	if !ts.active[i] {
+		return
+	}
+	ts.active[i] = false
+	for _, active := range ts.active {
+		if active {
+			ts.trim()
+			return
+		}
+	}
+	ts.buffer = nil
+	if !ts.exhausted {
+		ts.exhausted = true
+		ts.base.Abort()
+	}
+}
+
+func (ts *teeSource[T]) reset() {
+	ts.base.Reset()
+	ts.buffer = nil
+	ts.offset = 0
+	ts.exhausted = false
+	for i := range ts.positions {
+		ts.positions[i] = 0
+		ts.active[i] = true
+	}
+}
+
+func (ts *teeSource[T]) size(i int) IteratorSize {
+	if !ts.active[i] {
+		return NewSize(0)
+	}
+	buffered := NewSize(ts.offset + len(ts.buffer) - ts.positions[i])
+	if ts.exhausted {
+		return buffered
+	}
+	return addSize(buffered, ts.base.Size())
+}
+
+// teeIter is one of the iterators produced by [Tee].
+type teeIter[T any] struct {
+	source *teeSource[T]
+	index  int
+	value  T
+}
+
+func (ti *teeIter[T]) Next() (ok bool) {
+	ti.value, ok = ti.source.next(ti.index)
+	return
+}
+
+func (ti *teeIter[T]) Value() T {
+	return ti.value
+}
+
+func (ti *teeIter[T]) Abort() {
+	ti.source.abort(ti.index)
+}
+
+func (ti *teeIter[T]) Reset() {
+	ti.source.reset()
+}
+
+func (ti *teeIter[T]) Size() IteratorSize {
+	return ti.source.size(ti.index)
+}
+
+func (ti *teeIter[T]) SeqOK() bool { return false }
+
+func (ti *teeIter[T]) Seq() iter.Seq[T] {
+	return Seq(ti)
+}
+
+// memoIter records the elements of an iterator as they are read, so that they
+// can be replayed after a reset.
+type memoIter[T any] struct {
+	base     CoreIterator[T]
+	cache    []T
+	pos      int
+	complete bool
+	done     bool
+	value    T
+}
+
+func (mi *memoIter[T]) Next() bool {
+	if mi.done {
+		return false
+	}
+	if mi.pos < len(mi.cache) {
+		mi.value = mi.cache[mi.pos]
+		mi.pos++
+		return true
+	}
+	if mi.complete || !mi.base.Next() {
+		mi.complete = true
+		mi.done = true
+		return false
+	}
+	mi.value = mi.base.Value()
+	mi.cache = append(mi.cache, mi.value)
+	mi.pos++
+	return true
+}
+
+func (mi *memoIter[T]) Value() T {
+	return mi.value
+}
+
+func (mi *memoIter[T]) Abort() {
+	mi.done = true
+	if !mi.complete {
+		mi.complete = true
+		mi.base.Abort()
+	}
+}
+
+// Reset rewinds to the first recorded element, without resetting the
+// underlying iterator.
+func (mi *memoIter[T]) Reset() {
+	mi.pos = 0
+	mi.done = false
+}
+
+func (mi *memoIter[T]) Size() IteratorSize {
+	if mi.done {
+		return NewSize(0)
+	}
+	cached := NewSize(len(mi.cache) - mi.pos)
+	if mi.complete {
+		return cached
+	}
+	return addSize(cached, mi.base.Size())
+}
+
+func (mi *memoIter[T]) SeqOK() bool { return false }
+
+// Seq ends the pass, rather than aborting, if the loop is exited early, so
+// that a later pass can continue reading from the underlying iterator.
+func (mi *memoIter[T]) Seq() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for mi.Next() {
+			if !yield(mi.value) {
+				mi.done = true
+				break
+			}
+		}
+	}
+}
+
+// Tee splits an iterator into n independent iterators, each of which produces
+// all the elements of the original. The original iterator is consumed only
+// once; elements read from it are held in a shared buffer until every one of
+// the n iterators has consumed them, so memory use is bounded by the distance
+// between the iterator that is furthest ahead and the one that is furthest
+// behind. An iterator that is aborted no longer holds elements in the buffer;
+// once all of them have been aborted, the original iterator is aborted too.
+// Calling Reset() on any of the iterators resets the original iterator and all
+// n iterators along with it. The original iterator should not be used
+// directly once it has been split, and the iterators returned are not safe
+// for concurrent use. This function will panic with [ErrInvalidIteratorRange]
+// if n is negative. E.g.
+//
+//	itrs := iterator.Tee(iterator.Of(1, 2, 3), 2)
+//	first := itrs[0].Collect()  // []int{1,2,3}
+//	second := itrs[1].Collect() // []int{1,2,3}
+func Tee[T any](itr CoreIterator[T], n int) []Iterator[T] {
+	if n < 0 {
+		panic(fmt.Errorf("%w: tee count %d is negative", ErrInvalidIteratorRange, n))
+	}
+	source := &teeSource[T]{base: itr, positions: make([]int, n), active: make([]bool, n)}
+	for i := range source.active {
+		source.active[i] = true
+	}
+	result := make([]Iterator[T], n)
+	for i := range result {
+		result[i] = NewDefaultIterator(&teeIter[T]{source: source, index: i})
+	}
+	return result
+}
+
+// Memoize wraps an iterator so that its elements are recorded as they are
+// read, and calling Reset() replays them from the start rather than resetting
+// the underlying iterator. This makes it possible to make several passes over
+// a one-shot iterator, such as one built with [New]. If a pass ends before the
+// underlying iterator is exhausted, including by breaking out of a range loop
+// over Seq(), a later pass replays the recorded elements and then continues
+// reading from where it left off. However, if Abort() is called before the
+// first pass is complete, the underlying iterator is aborted and subsequent
+// passes replay only the elements read before that.
+// Every element read is retained until the iterator is discarded, so memory
+// use grows with the number of elements; it should not be used with very
+// large or infinite iterators.
+func Memoize[T any](itr CoreIterator[T]) Iterator[T] {
+	return NewDefaultIterator(&memoIter[T]{base: itr})
+}
@@ -0,0 +1,314 @@
+package iterator
+
+import (
+	"iter"
+
+	"github.com/robdavid/genutil-go/opt"
+)
+
+type zipIter[A any, B any] struct {
+	a     CoreIterator[A]
+	b     CoreIterator[B]
+	key   A
+	value B
+}
+
+func (zi *zipIter[A, B]) Next() bool {
+	if zi.a.Next() && zi.b.Next() {
+		zi.key = zi.a.Value()
+		zi.value = zi.b.Value()
+		return true
+	}
+	return false
+}
+
+func (zi *zipIter[A, B]) Key() A {
+	return zi.key
+}
+
+func (zi *zipIter[A, B]) Value() B {
+	return zi.value
+}
+
+func (zi *zipIter[A, B]) Abort() {
+	zi.a.Abort()
+	zi.b.Abort()
+}
+
+func (zi *zipIter[A, B]) Reset() {
+	zi.a.Reset()
+	zi.b.Reset()
+}
+
+func (zi *zipIter[A, B]) Size() IteratorSize {
+	return minSize(zi.a.Size(), zi.b.Size())
+}
+
+// Lockstep iteration requires the use of Next() on at least one of the
+// iterators.
+func (zi *zipIter[A, B]) SeqOK() bool { return false }
+
+func (zi *zipIter[A, B]) Seq() iter.Seq[B] {
+	return Seq(zi)
+}
+
+func (zi *zipIter[A, B]) Seq2() iter.Seq2[A, B] {
+	return func(yield func(A, B) bool) {
+		for zi.Next() {
+			if !yield(zi.key, zi.value) {
+				zi.Abort()
+				break
+			}
+		}
+	}
+}
+
+type zipWithIter[A any, B any, C any] struct {
+	zipIter[A, B]
+	zipper func(A, B) C
+	zipped C
+}
+
+func (zwi *zipWithIter[A, B, C]) Next() bool {
+	if zwi.zipIter.Next() {
+		zwi.zipped = zwi.zipper(zwi.key, zwi.value)
+		return true
+	}
+	return false
+}
+
+func (zwi *zipWithIter[A, B, C]) Value() C {
+	return zwi.zipped
+}
+
+func (zwi *zipWithIter[A, B, C]) Seq() iter.Seq[C] {
+	return Seq(zwi)
+}
+
+type zipLongestIter[A any, B any] struct {
+	a            CoreIterator[A]
+	b            CoreIterator[B]
+	aDone, bDone bool
+	key          opt.Val[A]
+	value        opt.Val[B]
+}
+
+func (zli *zipLongestIter[A, B]) Next() bool {
+	if !zli.aDone && zli.a.Next() {
+		zli.key = opt.Value(zli.a.Value())
+	} else {
+		zli.aDone = true
+		zli.key = opt.Empty[A]()
+	}
+	if !zli.bDone && zli.b.Next() {
+		zli.value = opt.Value(zli.b.Value())
+	} else {
+		zli.bDone = true
+		zli.value = opt.Empty[B]()
+	}
+	return !(zli.aDone && zli.bDone)
+}
+
+func (zli *zipLongestIter[A, B]) Key() opt.Val[A] {
+	return zli.key
+}
+
+func (zli *zipLongestIter[A, B]) Value() opt.Val[B] {
+	return zli.value
+}
+
+func (zli *zipLongestIter[A, B]) Abort() {
+	zli.a.Abort()
+	zli.b.Abort()
+	zli.aDone = true
+	zli.bDone = true
+}
+
+func (zli *zipLongestIter[A, B]) Reset() {
+	zli.a.Reset()
+	zli.b.Reset()
+	zli.aDone = false
+	zli.bDone = false
+}
+
+func (zli *zipLongestIter[A, B]) Size() IteratorSize {
+	aSize, bSize := NewSize(0), NewSize(0)
+	if !zli.aDone {
+		aSize = zli.a.Size()
+	}
+	if !zli.bDone {
+		bSize = zli.b.Size()
+	}
+	return maxSize(aSize, bSize)
+}
+
+func (zli *zipLongestIter[A, B]) SeqOK() bool { return false }
+
+func (zli *zipLongestIter[A, B]) Seq() iter.Seq[opt.Val[B]] {
+	return Seq(zli)
+}
+
+func (zli *zipLongestIter[A, B]) Seq2() iter.Seq2[opt.Val[A], opt.Val[B]] {
+	return func(yield func(opt.Val[A], opt.Val[B]) bool) {
+		for zli.Next() {
+			if !yield(zli.key, zli.value) {
+				zli.Abort()
+				break
+			}
+		}
+	}
+}
+
+// unzipShared holds the state shared between the two iterators produced by
+// Unzip. Key/value pairs read from the source by one iterator are buffered
+// until they have also been read by the other one, unless the other iterator
+// has been aborted.
+type unzipShared[K any, V any] struct {
+	source  CoreIterator2[K, V]
+	buffer  []KeyValue[K, V]
+	base    int
+	pos     [2]int
+	aborted [2]bool
+}
+
+func (us *unzipShared[K, V]) next(side int) (kv KeyValue[K, V], ok bool) {
+	if us.aborted[side] {
+		return
+	}
+	if us.pos[side] < us.base+len(us.buffer) {
+		kv = us.buffer[us.pos[side]-us.base]
+	} else if us.source.Next() {
+		kv = KVOf(us.source.Key(), us.source.Value())
+		if !us.aborted[1-side] {
+			us.buffer = append(us.buffer, kv)
+		}
+	} else {
+		return
+	}
+	us.pos[side]++
+	us.trim()
+	return kv, true
+}
+
+// trim discards buffered pairs that have been read by all iterators that have
+// not been aborted.
+func (us *unzipShared[K, V]) trim() {
+	lo := -1
+	for side := range us.pos {
+		if !us.aborted[side] && (lo < 0 || us.pos[side] < lo) {
+			lo = us.pos[side]
+		}
+	}
+	if lo < 0 {
+		us.buffer = nil
+		return
+	}
+	if n := min(lo-us.base, len(us.buffer)); n > 0 {
+		us.buffer = us.buffer[n:]
+	}
+	if len(us.buffer) == 0 {
+		us.buffer = nil
+	}
+	us.base = lo
+}
+
+func (us *unzipShared[K, V]) abort(side int) {
+	us.aborted[side] = true
+	if us.aborted[1-side] {
+		us.source.Abort()
+	}
+	us.trim()
+}
+
+func (us *unzipShared[K, V]) reset() {
+	us.source.Reset()
+	us.buffer = nil
+	us.base = 0
+	us.pos = [2]int{}
+	us.aborted = [2]bool{}
+}
+
+func (us *unzipShared[K, V]) size(side int) IteratorSize {
+	if us.aborted[side] {
+		return NewSize(0)
+	}
+	return addSize(us.source.Size(), NewSize(us.base+len(us.buffer)-us.pos[side]))
+}
+
+type unzipKeys[K any, V any] struct {
+	shared *unzipShared[K, V]
+	key    K
+}
+
+func (uk *unzipKeys[K, V]) Next() bool {
+	kv, ok := uk.shared.next(0)
+	if ok {
+		uk.key = kv.Key
+	}
+	return ok
+}
+
+func (uk *unzipKeys[K, V]) Value() K           { return uk.key }
+func (uk *unzipKeys[K, V]) Abort()             { uk.shared.abort(0) }
+func (uk *unzipKeys[K, V]) Reset()             { uk.shared.reset() }
+func (uk *unzipKeys[K, V]) Size() IteratorSize { return uk.shared.size(0) }
+func (uk *unzipKeys[K, V]) SeqOK() bool        { return false }
+func (uk *unzipKeys[K, V]) Seq() iter.Seq[K]   { return Seq(uk) }
+
+type unzipValues[K any, V any] struct {
+	shared *unzipShared[K, V]
+	value  V
+}
+
+func (uv *unzipValues[K, V]) Next() bool {
+	kv, ok := uv.shared.next(1)
+	if ok {
+		uv.value = kv.Value
+	}
+	return ok
+}
+
+func (uv *unzipValues[K, V]) Value() V           { return uv.value }
+func (uv *unzipValues[K, V]) Abort()             { uv.shared.abort(1) }
+func (uv *unzipValues[K, V]) Reset()             { uv.shared.reset() }
+func (uv *unzipValues[K, V]) Size() IteratorSize { return uv.shared.size(1) }
+func (uv *unzipValues[K, V]) SeqOK() bool        { return false }
+func (uv *unzipValues[K, V]) Seq() iter.Seq[V]   { return Seq(uv) }
+
+// Zip takes two iterators and builds an [Iterator2] that yields pairs of
+// elements, one from each iterator, in lockstep. The elements of the first
+// iterator form the keys, and those of the second iterator the values. The
+// resulting iterator ends when either of the input iterators end, and so its
+// size is the smaller of the sizes of the two input iterators.
+func Zip[A any, B any](a CoreIterator[A], b CoreIterator[B]) Iterator2[A, B] {
+	return NewDefaultIterator2(&zipIter[A, B]{a: a, b: b})
+}
+
+// ZipWith takes two iterators and a function zipper and builds an iterator
+// whose elements are the result of applying zipper to pairs of elements, one
+// from each iterator, taken in lockstep. The resulting iterator ends when
+// either of the input iterators end.
+func ZipWith[A any, B any, C any](a CoreIterator[A], b CoreIterator[B], zipper func(A, B) C) Iterator[C] {
+	return NewDefaultIterator(&zipWithIter[A, B, C]{zipIter: zipIter[A, B]{a: a, b: b}, zipper: zipper})
+}
+
+// ZipLongest is a variation on [Zip] that continues until both of the input
+// iterators end. The keys and values are wrapped in [opt.Val] options; once an
+// input iterator ends, the corresponding key or value will be empty. The size
+// of the resulting iterator is the larger of the sizes of the two input
+// iterators.
+func ZipLongest[A any, B any](a CoreIterator[A], b CoreIterator[B]) Iterator2[opt.Val[A], opt.Val[B]] {
+	return NewDefaultIterator2(&zipLongestIter[A, B]{a: a, b: b})
+}
+
+// Unzip splits an [Iterator2] into two iterators, one yielding the keys and
+// the other the values. The two iterators may be consumed independently; any
+// pair read from the source iterator by one of them is buffered until it has
+// been read by the other. If one of the iterators is aborted, no further
+// buffering is performed on its behalf, and once both have been aborted the
+// source iterator is aborted. Resetting either iterator resets both.
+func Unzip[K any, V any](itr CoreIterator2[K, V]) (Iterator[K], Iterator[V]) {
+	shared := &unzipShared[K, V]{source: itr}
+	return NewDefaultIterator(&unzipKeys[K, V]{shared: shared}),
+		NewDefaultIterator(&unzipValues[K, V]{shared: shared})
+}
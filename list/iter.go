@@ -0,0 +1,142 @@
+package list
+
+import (
+	"iter"
+
+	"github.com/robdavid/genutil-go/iterator"
+)
+
+// nodeIter is a double ended iterator over the nodes of a list. The front and
+// back nodes are the next ones to be produced from each end, and remain is the
+// number of nodes between them, inclusive. These are taken from the list when
+// iteration starts. Until NextBack() is first called, the iterator may be
+// consumed by ranging over Seq() directly.
+type nodeIter[T any] struct {
+	lst         List[T]
+	front, back *Node[T]
+	remain      int
+	node        *Node[T]
+	started     bool
+	backward    bool
+}
+
+func newNodeIter[T any](lst List[T]) *nodeIter[T] {
+	return &nodeIter[T]{lst: lst}
+}
+
+func (ni *nodeIter[T]) start() {
+	if !ni.started {
+		ni.started = true
+		if ni.lst.inner != nil {
+			ni.front, ni.back, ni.remain = ni.lst.first, ni.lst.last, ni.lst.size
+		}
+	}
+}
+
+func (ni *nodeIter[T]) Next() bool {
+	ni.start()
+	if ni.remain == 0 {
+		return false
+	}
+	ni.node = ni.front
+	ni.front = ni.front.next
+	ni.remain--
+	return true
+}
+
+func (ni *nodeIter[T]) NextBack() bool {
+	ni.start()
+	ni.backward = true
+	if ni.remain == 0 {
+		return false
+	}
+	ni.node = ni.back
+	ni.back = ni.back.prev
+	ni.remain--
+	return true
+}
+
+func (ni *nodeIter[T]) Value() *Node[T] {
+	return ni.node
+}
+
+func (ni *nodeIter[T]) Abort() {
+	ni.started = true
+	ni.remain = 0
+}
+
+func (ni *nodeIter[T]) Reset() {
+	ni.started = false
+	ni.backward = false
+	ni.front, ni.back, ni.remain = nil, nil, 0
+}
+
+func (ni *nodeIter[T]) Size() iterator.IteratorSize {
+	if !ni.started {
+		return iterator.NewSize(ni.lst.Len())
+	}
+	return iterator.NewSize(ni.remain)
+}
+
+func (ni *nodeIter[T]) SeqOK() bool { return !ni.backward }
+
+// seqNode ranges over the remaining nodes from the front.
+func (ni *nodeIter[T]) seqNode(yield func(*Node[T]) bool) {
+	ni.start()
+	for ni.remain > 0 {
+		ni.node, ni.front = ni.front, ni.front.next
+		ni.remain--
+		if !yield(ni.node) {
+			break
+		}
+	}
+}
+
+func (ni *nodeIter[T]) Seq() iter.Seq[*Node[T]] {
+	if !ni.SeqOK() {
+		return iterator.Seq(ni)
+	}
+	return ni.seqNode
+}
+
+// valueIter is a double ended iterator over the values of a list.
+type valueIter[T any] struct {
+	*nodeIter[T]
+}
+
+func (vi valueIter[T]) Value() T {
+	if vi.node == nil {
+		var zero T
+		return zero
+	}
+	return vi.node.value
+}
+
+func (vi valueIter[T]) Seq() iter.Seq[T] {
+	if !vi.SeqOK() {
+		return iterator.Seq(vi)
+	}
+	return func(yield func(T) bool) {
+		vi.seqNode(func(node *Node[T]) bool { return yield(node.value) })
+	}
+}
+
+// mutValueIter is a double ended iterator over the values of a list that
+// allows the current value to be modified or deleted.
+type mutValueIter[T any] struct {
+	valueIter[T]
+}
+
+// Set modifies the value at the current node, if there is one.
+func (mi mutValueIter[T]) Set(value T) {
+	mi.node.Set(value)
+}
+
+// Delete removes the current node from the list. The iterator has already
+// moved past the node at either end, so iteration continues unaffected.
+func (mi mutValueIter[T]) Delete() {
+	if mi.node != nil {
+		mi.lst.Delete(mi.node)
+		mi.node = nil
+	}
+}
@@ -14,6 +14,7 @@ import (
 var ErrIndexError = errors.New("index out of bounds")
 var ErrNilNode = errors.New("node is nil")
 var ErrNilList = errors.New("list is nil")
+var ErrSpliceSelf = errors.New("cannot splice a list into itself")
 
 // Node is an element within a doubly linked list.
 type Node[T any] struct {
@@ -240,41 +241,32 @@ func (lst List[T]) RevSeqNode() iter.Seq[*Node[T]] {
 }
 
 // Iter returns an iterator over element values moving forwards in the list.
+// The iterator is double ended, and may be converted to an
+// [iterator.DoubleEndedIterator] to consume elements from the back of the list
+// as well as the front.
 func (lst List[T]) Iter() iterator.Iterator[T] {
 	if lst.inner == nil {
 		return iterator.Empty[T]()
 	}
-	remain := lst.size
-	return iterator.NewWithSize(
-		func(yield func(T) bool) {
-			for n := lst.first; n != nil; n = n.next {
-				if !yield(n.value) {
-					break
-				}
-				remain--
-			}
-		},
-		func() iterator.IteratorSize { return iterator.NewSize(remain) },
-	)
+	return iterator.NewDefaultDoubleEndedIterator[T](valueIter[T]{newNodeIter(lst)})
+}
+
+// IterMut returns a mutable iterator over element values moving forwards in
+// the list. The current value may be modified with Set(), or removed from the
+// list with Delete(), without disturbing the iteration.
+func (lst List[T]) IterMut() iterator.MutableIterator[T] {
+	return iterator.NewDefaultMutableIterator[T](mutValueIter[T]{valueIter[T]{newNodeIter(lst)}})
 }
 
 // IterNode returns an iterator over element nodes moving forwards in the list.
+// The iterator is double ended, and may be converted to an
+// [iterator.DoubleEndedIterator] to consume nodes from the back of the list as
+// well as the front.
 func (lst List[T]) IterNode() iterator.Iterator[*Node[T]] {
 	if lst.inner == nil {
 		return iterator.Empty[*Node[T]]()
 	}
-	remain := lst.size
-	return iterator.NewWithSize(
-		func(yield func(*Node[T]) bool) {
-			for n := lst.first; n != nil; n = n.next {
-				if !yield(n) {
-					break
-				}
-				remain--
-			}
-		},
-		func() iterator.IteratorSize { return iterator.NewSize(remain) },
-	)
+	return iterator.NewDefaultDoubleEndedIterator[*Node[T]](newNodeIter(lst))
 }
 
 // RevIter returns an iterator over element values moving backwards through the list
@@ -559,6 +551,222 @@ func (lst List[T]) Delete(node *Node[T]) {
 	lst.size--
 }
 
+// Splice moves all the elements of other into the list before the node at,
+// leaving other empty. If at is nil, the elements are moved to the end of the
+// list. This takes constant time, as the nodes of other are linked into the
+// list rather than copied. It will panic if other is the same list.
+func (lst List[T]) Splice(at *Node[T], other List[T]) {
+	if other.IsEmpty() {
+		return
+	}
+	if lst.inner == nil {
+		panic(ErrNilList)
+	}
+	if lst.inner == other.inner {
+		panic(ErrSpliceSelf)
+	}
+	start, end, size := other.first, other.last, other.size
+	other.Clear()
+	if at == nil {
+		if lst.last == nil {
+			lst.first = start
+		} else {
+			lst.last.next = start
+			start.prev = lst.last
+		}
+		lst.last = end
+	} else {
+		if at.prev == nil {
+			if lst.first == at {
+				lst.first = start
+			}
+		} else {
+			at.prev.next = start
+			start.prev = at.prev
+		}
+		end.next = at
+		at.prev = end
+	}
+	lst.size += size
+}
+
+// SplitAt divides the list in two at the given node. The list is truncated to
+// the elements before node, and returned as the first result. The elements
+// from node onwards are moved to a new list, returned as the second result. If
+// node is nil, the second list is empty. Finding the sizes of the two lists
+// takes time proportional to the length of the shorter one.
+func (lst List[T]) SplitAt(node *Node[T]) (List[T], List[T]) {
+	if lst.inner == nil {
+		panic(ErrNilList)
+	}
+	rest := Make[T]()
+	if node == nil {
+		return lst, rest
+	}
+	before, after := 0, 0
+	back, fwd := node.prev, node
+	for back != nil && fwd != nil {
+		before++
+		after++
+		back, fwd = back.prev, fwd.next
+	}
+	if back == nil {
+		after = lst.size - before
+	} else {
+		before = lst.size - after
+	}
+	rest.first, rest.last, rest.size = node, lst.last, after
+	if node.prev == nil {
+		lst.first, lst.last = nil, nil
+	} else {
+		lst.last = node.prev
+		node.prev.next = nil
+		node.prev = nil
+	}
+	lst.size = before
+	return lst, rest
+}
+
+// Reverse reverses the order of the elements of the list in place.
+func (lst List[T]) Reverse() {
+	if lst.inner == nil {
+		return
+	}
+	for node := lst.first; node != nil; node = node.prev {
+		node.next, node.prev = node.prev, node.next
+	}
+	lst.first, lst.last = lst.last, lst.first
+}
+
+// unlink detaches a node from its neighbours, without changing the list size.
+func (lst List[T]) unlink(node *Node[T]) {
+	if node.prev == nil {
+		lst.first = node.next
+	} else {
+		node.prev.next = node.next
+	}
+	if node.next == nil {
+		lst.last = node.prev
+	} else {
+		node.next.prev = node.prev
+	}
+}
+
+// linkBefore attaches a detached node before mark.
+func (lst List[T]) linkBefore(node, mark *Node[T]) {
+	node.prev, node.next = mark.prev, mark
+	if mark.prev == nil {
+		lst.first = node
+	} else {
+		mark.prev.next = node
+	}
+	mark.prev = node
+}
+
+// linkAfter attaches a detached node after mark.
+func (lst List[T]) linkAfter(node, mark *Node[T]) {
+	node.prev, node.next = mark, mark.next
+	if mark.next == nil {
+		lst.last = node
+	} else {
+		mark.next.prev = node
+	}
+	mark.next = node
+}
+
+// MoveToFront moves node, which must be in the list, to the start of the list.
+func (lst List[T]) MoveToFront(node *Node[T]) {
+	if node == nil {
+		panic(ErrNilNode)
+	}
+	if lst.first != node {
+		lst.unlink(node)
+		lst.linkBefore(node, lst.first)
+	}
+}
+
+// MoveToBack moves node, which must be in the list, to the end of the list.
+func (lst List[T]) MoveToBack(node *Node[T]) {
+	if node == nil {
+		panic(ErrNilNode)
+	}
+	if lst.last != node {
+		lst.unlink(node)
+		lst.linkAfter(node, lst.last)
+	}
+}
+
+// MoveBefore moves node to the position before mark. Both must be in the list.
+func (lst List[T]) MoveBefore(node, mark *Node[T]) {
+	if node == nil || mark == nil {
+		panic(ErrNilNode)
+	}
+	if node != mark && mark.prev != node {
+		lst.unlink(node)
+		lst.linkBefore(node, mark)
+	}
+}
+
+// MoveAfter moves node to the position after mark. Both must be in the list.
+func (lst List[T]) MoveAfter(node, mark *Node[T]) {
+	if node == nil || mark == nil {
+		panic(ErrNilNode)
+	}
+	if node != mark && mark.next != node {
+		lst.unlink(node)
+		lst.linkAfter(node, mark)
+	}
+}
+
+// SortFunc sorts the list in place, in ascending order as determined by the
+// cmp function, which should return a negative number when a < b, a positive
+// number when a > b and zero when a == b. The sort is stable, so equal
+// elements retain their original order. It is a bottom up merge sort that
+// relinks the existing nodes, taking O(n log n) time and constant extra space.
+func (lst List[T]) SortFunc(cmp func(a, b T) int) {
+	if lst.inner == nil || lst.first == nil {
+		return
+	}
+	head := lst.first
+	for width := 1; ; width *= 2 {
+		var merged, tail *Node[T]
+		merges := 0
+		for p := head; p != nil; {
+			merges++
+			q, psize := p, 0
+			for psize < width && q != nil {
+				psize++
+				q = q.next
+			}
+			qsize := width
+			for psize > 0 || (qsize > 0 && q != nil) {
+				var node *Node[T]
+				if psize > 0 && (qsize == 0 || q == nil || cmp(p.value, q.value) <= 0) {
+					node, p = p, p.next
+					psize--
+				} else {
+					node, q = q, q.next
+					qsize--
+				}
+				if tail == nil {
+					merged = node
+				} else {
+					tail.next = node
+				}
+				node.prev = tail
+				tail = node
+			}
+			p = q
+		}
+		tail.next = nil
+		head = merged
+		if merges <= 1 {
+			lst.first, lst.last = head, tail
+			return
+		}
+	}
+}
+
 // Chain links the provided into a series of nodes, returning the first and last nodes.
 func Chain[T any](values ...T) (first *Node[T], last *Node[T]) {
 	if len(values) == 0 {
@@ -510,3 +510,172 @@ func BenchmarkSeqCollect(b *testing.B) {
 		b.Fail()
 	}
 }
+
+func TestIterDoubleEnded(t *testing.T) {
+	lst := list.Of(1, 2, 3, 4, 5)
+	itr, ok := lst.Iter().(iterator.DoubleEndedIterator[int])
+	assert.True(t, ok)
+	assert.True(t, itr.NextBack())
+	assert.Equal(t, 5, itr.Value())
+	assert.True(t, itr.Next())
+	assert.Equal(t, 1, itr.Value())
+	assert.True(t, itr.Size().IsKnownToBe(3))
+	assert.True(t, itr.NextBack())
+	assert.Equal(t, 4, itr.Value())
+	assert.Equal(t, []int{2, 3}, itr.Collect())
+	assert.False(t, itr.NextBack())
+	itr.Reset()
+	assert.Equal(t, []int{5, 4, 3, 2, 1}, iterator.Rev(itr).Collect())
+	var zero list.List[int]
+	assert.Empty(t, iterator.Rev(zero.Iter()).Collect())
+}
+
+func TestIterSeqOK(t *testing.T) {
+	lst := list.Of(1, 2, 3)
+	itr := lst.Iter()
+	assert.True(t, itr.SeqOK())
+	assert.True(t, itr.Size().IsKnownToBe(3))
+	// Asking for the size does not fix the bounds of the iteration
+	lst.Append(4)
+	assert.True(t, itr.Size().IsKnownToBe(4))
+	var actual []int
+	for v := range itr.Seq() {
+		actual = append(actual, v)
+	}
+	assert.Equal(t, []int{1, 2, 3, 4}, actual)
+	itr.Reset()
+	rev := itr.(iterator.DoubleEndedIterator[int])
+	assert.True(t, rev.NextBack())
+	assert.False(t, rev.SeqOK())
+	assert.Equal(t, []int{1, 2, 3}, rev.Collect())
+	itr.Reset()
+	assert.True(t, itr.SeqOK())
+	var zero list.List[int]
+	assert.True(t, zero.Iter().Size().IsKnownToBe(0))
+}
+
+func TestIterNodeDoubleEnded(t *testing.T) {
+	lst := list.Of("a", "b", "c")
+	assert.Equal(t, []string{"c", "b", "a"}, iterator.Map(iterator.Rev(lst.IterNode()), list.NodeToValue).Collect())
+}
+
+func TestIterMut(t *testing.T) {
+	lst := list.Of(1, 2, 3, 4, 5, 6)
+	itr := lst.IterMut()
+	for itr.Next() {
+		if itr.Value()%2 == 0 {
+			itr.Delete()
+			itr.Delete()
+		} else {
+			itr.Set(itr.Value() * 10)
+		}
+	}
+	assert.Equal(t, []int{10, 30, 50}, lst.Iter().Collect())
+	assert.Equal(t, 3, lst.Len())
+	itr.Reset()
+	assert.True(t, itr.Next())
+	itr.Delete()
+	assert.Equal(t, []int{30, 50}, lst.Iter().Collect())
+	assert.Equal(t, 30, lst.First().Get())
+	assert.Equal(t, []int{50, 30}, iterator.Rev(lst.IterMut()).Collect())
+}
+
+func TestIterMutDeleteAll(t *testing.T) {
+	lst := list.Of("a", "b", "c")
+	itr := lst.IterMut()
+	for itr.Next() {
+		itr.Delete()
+	}
+	assert.True(t, lst.IsEmpty())
+	assert.Nil(t, lst.First())
+	assert.Nil(t, lst.Last())
+}
+
+// assertList checks the contents of a list in both directions, and its size.
+func assertList[T any](t *testing.T, expected []T, lst list.List[T]) {
+	t.Helper()
+	if expected == nil {
+		expected = []T{}
+	}
+	assert.Equal(t, expected, lst.Iter().Collect())
+	assert.Equal(t, slices.Reverse(expected), lst.RevIter().Collect())
+	assert.Equal(t, len(expected), lst.Len())
+}
+
+func TestSplice(t *testing.T) {
+	lst := list.Of(1, 2, 3)
+	other := list.Of(10, 11)
+	lst.Splice(lst.At(1), other)
+	assertList(t, []int{1, 10, 11, 2, 3}, lst)
+	assertList(t, nil, other)
+	lst.Splice(lst.First(), list.Of(0))
+	lst.Splice(nil, list.Of(4, 5))
+	assertList(t, []int{0, 1, 10, 11, 2, 3, 4, 5}, lst)
+	empty := list.Make[int]()
+	empty.Splice(nil, list.Of(7))
+	assertList(t, []int{7}, empty)
+	lst.Splice(nil, list.Make[int]())
+	assert.Equal(t, 8, lst.Len())
+	assert.PanicsWithError(t, list.ErrSpliceSelf.Error(), func() { lst.Splice(nil, lst) })
+}
+
+func TestSplitAt(t *testing.T) {
+	for n := range 6 {
+		lst := list.Of(0, 1, 2, 3, 4)
+		var node *list.Node[int]
+		if n < lst.Len() {
+			node = lst.At(n)
+		}
+		head, tail := lst.SplitAt(node)
+		assertList(t, slices.Range(0, n), head)
+		assertList(t, slices.Range(n, 5), tail)
+		assertList(t, slices.Range(0, n), lst)
+	}
+}
+
+func TestReverse(t *testing.T) {
+	lst := list.Of(1, 2, 3, 4)
+	lst.Reverse()
+	assertList(t, []int{4, 3, 2, 1}, lst)
+	single := list.Of(1)
+	single.Reverse()
+	assertList(t, []int{1}, single)
+	var zero list.List[int]
+	zero.Reverse()
+	assert.True(t, zero.IsEmpty())
+}
+
+func TestMove(t *testing.T) {
+	lst := list.Of(0, 1, 2, 3, 4)
+	lst.MoveToFront(lst.At(3))
+	assertList(t, []int{3, 0, 1, 2, 4}, lst)
+	lst.MoveToBack(lst.First())
+	assertList(t, []int{0, 1, 2, 4, 3}, lst)
+	lst.MoveBefore(lst.Last(), lst.At(3))
+	assertList(t, []int{0, 1, 2, 3, 4}, lst)
+	lst.MoveAfter(lst.First(), lst.Last())
+	assertList(t, []int{1, 2, 3, 4, 0}, lst)
+	lst.MoveAfter(lst.At(1), lst.At(1))
+	lst.MoveBefore(lst.At(1), lst.At(2))
+	lst.MoveToFront(lst.First())
+	lst.MoveToBack(lst.Last())
+	assertList(t, []int{1, 2, 3, 4, 0}, lst)
+	assert.PanicsWithError(t, list.ErrNilNode.Error(), func() { lst.MoveBefore(nil, lst.First()) })
+}
+
+func TestSortFunc(t *testing.T) {
+	type pair struct{ key, seq int }
+	input := []int{5, 3, 9, 1, 3, 7, 5, 0, 2, 9, 1}
+	lst := list.Make[pair]()
+	for i, n := range input {
+		lst.Append(pair{n, i})
+	}
+	lst.SortFunc(func(a, b pair) int { return a.key - b.key })
+	expected := []pair{{0, 7}, {1, 3}, {1, 10}, {2, 8}, {3, 1}, {3, 4}, {5, 0}, {5, 6}, {7, 5}, {9, 2}, {9, 9}}
+	assertList(t, expected, lst)
+	for n := range 20 {
+		lst := list.Of(slices.Range(n, 0)...)
+		lst.SortFunc(func(a, b int) int { return a - b })
+		assertList(t, slices.Range(1, n+1), lst)
+	}
+}
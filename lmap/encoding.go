@@ -0,0 +1,265 @@
+package lmap
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/robdavid/genutil-go/internal/decodeoptions"
+	"github.com/robdavid/genutil-go/internal/typehelper"
+	"gopkg.in/yaml.v2"
+)
+
+var ErrUnsupportedKey = errors.New("unsupported key type")
+var ErrNotObject = errors.New("value is not an object")
+
+// DecodeOption is an option that controls how a LinkedMap is decoded by
+// [FromJSON], or by FromYAML in the lmap/yamlv3 package.
+type DecodeOption = decodeoptions.Option
+
+// NestedMaps is an option that determines whether objects nested within values
+// of type any are decoded as LinkedMap[string, any] (true), so that their key
+// order is preserved too, or as map[string]any (false). It applies at any depth,
+// including to objects within arrays. Defaults to false.
+func NestedMaps(nested bool) DecodeOption {
+	return func(o *decodeoptions.Options) { o.Nested = nested }
+}
+
+// encodeKey converts a key to a string in the same way as encoding/json does
+// for the keys of a Go map.
+func encodeKey[K comparable](k K) (string, error) {
+	rv := reflect.ValueOf(&k).Elem()
+	if rv.Kind() == reflect.String {
+		return rv.String(), nil
+	}
+	if tm, ok := any(k).(encoding.TextMarshaler); ok {
+		text, err := tm.MarshalText()
+		return string(text), err
+	}
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	}
+	return "", fmt.Errorf("%w: %T", ErrUnsupportedKey, k)
+}
+
+// decodeKey converts a string to a key in the same way as encoding/json does
+// for the keys of a Go map.
+func decodeKey[K comparable](s string) (k K, err error) {
+	if tu, ok := any(&k).(encoding.TextUnmarshaler); ok {
+		err = tu.UnmarshalText([]byte(s))
+		return
+	}
+	rv := reflect.ValueOf(&k).Elem()
+	switch rv.Kind() {
+	case reflect.String:
+		rv.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var n int64
+		if n, err = strconv.ParseInt(s, 10, 64); err == nil && rv.OverflowInt(n) {
+			err = fmt.Errorf("key %s overflows %T", s, k)
+		}
+		rv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var n uint64
+		if n, err = strconv.ParseUint(s, 10, 64); err == nil && rv.OverflowUint(n) {
+			err = fmt.Errorf("key %s overflows %T", s, k)
+		}
+		rv.SetUint(n)
+	default:
+		err = fmt.Errorf("%w: %T", ErrUnsupportedKey, k)
+	}
+	return
+}
+
+// MarshalJSON implements JSON marshaling of a LinkedMap as a JSON object,
+// with its members in the same order as the keys of the map. Keys are
+// converted to strings in the same way as those of a Go map. A nil map is
+// marshaled as "null".
+func (lm LinkedMap[K, V]) MarshalJSON() ([]byte, error) {
+	if lm.IsNil() {
+		return []byte("null"), nil
+	}
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for k, v := range lm.Seq() {
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		key, err := encodeKey(k)
+		if err != nil {
+			return nil, err
+		}
+		keyJSON, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		valueJSON, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(keyJSON)
+		buf.WriteByte(':')
+		buf.Write(valueJSON)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON implements JSON unmarshaling of a JSON object into a
+// LinkedMap, replacing any existing contents. The keys of the map are placed
+// in the order in which they appear in the object. An input of "null"
+// unmarshals as a nil map. Use [FromJSON] to decode nested objects as
+// LinkedMaps too.
+func (lm *LinkedMap[K, V]) UnmarshalJSON(data []byte) error {
+	result := lm.Make()
+	if err := decodeJSON(data, &result, decodeoptions.Options{}); err != nil {
+		return err
+	}
+	*lm = result
+	return nil
+}
+
+// FromJSON creates a new LinkedMap instance from a JSON object, as
+// [LinkedMap.UnmarshalJSON] does, with the provided options.
+func FromJSON[K comparable, V any](data []byte, opts ...DecodeOption) (LinkedMap[K, V], error) {
+	result := Make[K, V]()
+	err := decodeJSON(data, &result, decodeoptions.Combine(opts))
+	return result, err
+}
+
+func decodeJSON[K comparable, V any](data []byte, result *LinkedMap[K, V], o decodeoptions.Options) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		*result = LinkedMap[K, V]{}
+		return nil
+	}
+	if tok != json.Delim('{') {
+		return fmt.Errorf("%w: JSON %v", ErrNotObject, tok)
+	}
+	for dec.More() {
+		if tok, err = dec.Token(); err != nil {
+			return err
+		}
+		k, err := decodeKey[K](tok.(string))
+		if err != nil {
+			return err
+		}
+		var v V
+		if o.Nested && typehelper.IsAny[V]() {
+			var nested any
+			if nested, err = decodeJSONAny(dec); err != nil {
+				return err
+			}
+			v, _ = nested.(V)
+		} else if err = dec.Decode(&v); err != nil {
+			return err
+		}
+		result.Put(k, v)
+	}
+	_, err = dec.Token()
+	return err
+}
+
+// decodeJSONAny decodes the next JSON value as encoding/json does into a value
+// of type any, except that objects are decoded as LinkedMap[string, any].
+func decodeJSONAny(dec *json.Decoder) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok {
+	case json.Delim('{'):
+		result := Make[string, any]()
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeJSONAny(dec)
+			if err != nil {
+				return nil, err
+			}
+			result.Put(key.(string), value)
+		}
+		_, err = dec.Token()
+		return result, err
+	case json.Delim('['):
+		result := []any{}
+		for dec.More() {
+			value, err := decodeJSONAny(dec)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, value)
+		}
+		_, err = dec.Token()
+		return result, err
+	default:
+		return tok, nil
+	}
+}
+
+// MarshalYAML implements YAML marshaling of a LinkedMap for the
+// https://pkg.go.dev/gopkg.in/yaml.v2 YAML parser. The map is marshaled as a
+// YAML mapping, with its entries in the same order as the keys of the map. A
+// nil map is marshaled as null. For gopkg.in/yaml.v3, use the LinkedMap
+// wrapper in the lmap/yamlv3 package.
+func (lm LinkedMap[K, V]) MarshalYAML() (any, error) {
+	if lm.IsNil() {
+		return nil, nil
+	}
+	items := make(yaml.MapSlice, 0, lm.Len())
+	for k, v := range lm.Seq() {
+		items = append(items, yaml.MapItem{Key: k, Value: v})
+	}
+	return items, nil
+}
+
+// UnmarshalYAML implements YAML unmarshaling of a YAML mapping into a
+// LinkedMap for the https://pkg.go.dev/gopkg.in/yaml.v2 YAML parser, replacing
+// any existing contents. The keys of the map are placed in the order in which
+// they appear in the mapping.
+func (lm *LinkedMap[K, V]) UnmarshalYAML(unmarshal func(any) error) error {
+	var items yaml.MapSlice
+	if err := unmarshal(&items); err != nil {
+		return err
+	}
+	var values map[K]V
+	if err := unmarshal(&values); err != nil {
+		return err
+	}
+	result := lm.Make()
+	for _, item := range items {
+		k, err := yamlKey[K](item.Key)
+		if err != nil {
+			return err
+		}
+		result.Put(k, values[k])
+	}
+	*lm = result
+	return nil
+}
+
+// yamlKey converts a key decoded by yaml.v2 into a value of type any to type
+// K, in the same way that yaml.v2 decodes the keys of a map[K]V.
+func yamlKey[K comparable](key any) (k K, err error) {
+	if k, ok := key.(K); ok {
+		return k, nil
+	}
+	data, err := yaml.Marshal(key)
+	if err == nil {
+		err = yaml.Unmarshal(data, &k)
+	}
+	return
+}
@@ -0,0 +1,53 @@
+package lmap
+
+import "github.com/robdavid/genutil-go/iterator"
+
+// GroupBy collects the elements of an iterator into a LinkedMap of slices,
+// keyed by the result of applying keyFn to each element. Keys are placed in
+// the order in which they are first seen, and the elements in each slice
+// appear in the order in which they were produced by the iterator. If the
+// iterator is known to be of infinite size, this function will panic with
+// [iterator.ErrSizeInfinite].
+func GroupBy[T any, K comparable](itr iterator.CoreIterator[T], keyFn func(T) K) LinkedMap[K, []T] {
+	if itr.Size().IsInfinite() {
+		panic(iterator.ErrSizeInfinite)
+	}
+	result := Make[K, []T]()
+	if itr.SeqOK() {
+		for v := range itr.Seq() {
+			k := keyFn(v)
+			result.Put(k, append(result.Get(k), v))
+		}
+	} else {
+		for itr.Next() {
+			v := itr.Value()
+			k := keyFn(v)
+			result.Put(k, append(result.Get(k), v))
+		}
+	}
+	return result
+}
+
+// CountBy counts the elements of an iterator by the result of applying keyFn
+// to each element, returning a LinkedMap of the number of elements with each
+// key. Keys are placed in the order in which they are first seen. If the
+// iterator is known to be of infinite size, this function will panic with
+// [iterator.ErrSizeInfinite].
+func CountBy[T any, K comparable](itr iterator.CoreIterator[T], keyFn func(T) K) LinkedMap[K, int] {
+	if itr.Size().IsInfinite() {
+		panic(iterator.ErrSizeInfinite)
+	}
+	result := Make[K, int]()
+	if itr.SeqOK() {
+		for v := range itr.Seq() {
+			k := keyFn(v)
+			result.Put(k, result.Get(k)+1)
+		}
+	} else {
+		for itr.Next() {
+			k := keyFn(itr.Value())
+			result.Put(k, result.Get(k)+1)
+		}
+	}
+	return result
+}
@@ -0,0 +1,146 @@
+package lmap
+
+import (
+	"iter"
+
+	"github.com/robdavid/genutil-go/iterator"
+	"github.com/robdavid/genutil-go/list"
+)
+
+// entryIter is a double ended iterator over the key and value pairs of a
+// LinkedMap, which walks the list of keys from either end. Until NextBack() is
+// first called, the iterator may be consumed by ranging over Seq() or Seq2()
+// directly.
+type entryIter[K comparable, V any] struct {
+	lm          LinkedMap[K, V]
+	front, back *list.Node[K]
+	remain      int
+	node        *list.Node[K]
+	key         K
+	value       V
+	started     bool
+	backward    bool
+}
+
+func (ei *entryIter[K, V]) start() {
+	if !ei.started {
+		ei.started = true
+		ei.front, ei.back, ei.remain = ei.lm.keys.First(), ei.lm.keys.Last(), ei.lm.Len()
+	}
+}
+
+func (ei *entryIter[K, V]) visit(node *list.Node[K]) {
+	ei.node = node
+	ei.key = node.Get()
+	ei.value = ei.lm.kv[ei.key].value
+	ei.remain--
+}
+
+func (ei *entryIter[K, V]) Next() bool {
+	ei.start()
+	if ei.remain == 0 {
+		return false
+	}
+	ei.visit(ei.front)
+	ei.front = ei.front.Next()
+	return true
+}
+
+func (ei *entryIter[K, V]) NextBack() bool {
+	ei.start()
+	ei.backward = true
+	if ei.remain == 0 {
+		return false
+	}
+	ei.visit(ei.back)
+	ei.back = ei.back.Prev()
+	return true
+}
+
+func (ei *entryIter[K, V]) Key() K {
+	return ei.key
+}
+
+func (ei *entryIter[K, V]) Value() V {
+	return ei.value
+}
+
+func (ei *entryIter[K, V]) Abort() {
+	ei.started = true
+	ei.remain = 0
+}
+
+func (ei *entryIter[K, V]) Reset() {
+	ei.started = false
+	ei.backward = false
+	ei.front, ei.back, ei.remain = nil, nil, 0
+	ei.node = nil
+}
+
+func (ei *entryIter[K, V]) Size() iterator.IteratorSize {
+	if !ei.started {
+		return iterator.NewSize(ei.lm.Len())
+	}
+	return iterator.NewSize(ei.remain)
+}
+
+func (ei *entryIter[K, V]) SeqOK() bool { return !ei.backward }
+
+// seq2 ranges over the remaining key and value pairs from the front.
+func (ei *entryIter[K, V]) seq2(yield func(K, V) bool) {
+	ei.start()
+	for ei.remain > 0 {
+		ei.visit(ei.front)
+		ei.front = ei.front.Next()
+		if !yield(ei.key, ei.value) {
+			break
+		}
+	}
+}
+
+func (ei *entryIter[K, V]) Seq() iter.Seq[V] {
+	if !ei.SeqOK() {
+		return iterator.Seq(ei)
+	}
+	return func(yield func(V) bool) {
+		ei.seq2(func(_ K, v V) bool { return yield(v) })
+	}
+}
+
+func (ei *entryIter[K, V]) Seq2() iter.Seq2[K, V] {
+	if !ei.SeqOK() {
+		return func(yield func(K, V) bool) {
+			for ei.Next() {
+				if !yield(ei.key, ei.value) {
+					ei.Abort()
+					break
+				}
+			}
+		}
+	}
+	return ei.seq2
+}
+
+// mutEntryIter is a double ended iterator over the key and value pairs of a
+// LinkedMap that allows the current value to be modified or deleted.
+type mutEntryIter[K comparable, V any] struct {
+	*entryIter[K, V]
+}
+
+// Set replaces the value associated with the current key, if there is one.
+func (mi mutEntryIter[K, V]) Set(v V) {
+	if mi.node != nil {
+		mi.lm.kv[mi.key] = makeValue(mi.node, v)
+		mi.value = v
+	}
+}
+
+// Delete removes the current key and its value from the map. The iterator has
+// already moved past the key at either end, so iteration continues unaffected.
+func (mi mutEntryIter[K, V]) Delete() {
+	if mi.node != nil {
+		mi.lm.keys.Delete(mi.node)
+		delete(mi.lm.kv, mi.key)
+		mi.node = nil
+	}
+}
@@ -21,10 +21,12 @@ func makeValue[K comparable, V any](k *list.Node[K], v V) value[K, V] {
 }
 
 // LinkedMap is map combined with linked list of keys which maintains a consistent
-// key order. Keys are placed in the order they are first inserted.
+// key order. Keys are placed in the order they are first inserted, unless the
+// map is in access order mode, as created by [MakeAccessOrder].
 type LinkedMap[K comparable, V any] struct {
-	kv   map[K]value[K, V]
-	keys list.List[K]
+	kv          map[K]value[K, V]
+	keys        list.List[K]
+	accessOrder bool
 }
 
 // Make creates a new empty LinkedMap instance.
@@ -35,6 +37,16 @@ func Make[K comparable, V any]() LinkedMap[K, V] {
 	}
 }
 
+// MakeAccessOrder creates a new empty LinkedMap instance in access order mode.
+// In this mode, a key is moved to the end of the key order whenever it is
+// accessed by Get, GetOk or Put, so that the keys are ordered from least to
+// most recently used. Keys may be read without changing the order using Peek.
+func MakeAccessOrder[K comparable, V any]() LinkedMap[K, V] {
+	result := Make[K, V]()
+	result.accessOrder = true
+	return result
+}
+
 // FromSeq2 creates a new LinkedMap instance populated with keys and values taken from
 // the provided [iter.Seq2][K,V] iterator.
 //
@@ -104,22 +116,37 @@ func (lm LinkedMap[K, V]) IsNil() bool {
 	return lm.keys.IsNil()
 }
 
-// Make creates an empty map of the same type.
+// IsAccessOrder returns true if the map is in access order mode.
+func (lm LinkedMap[K, V]) IsAccessOrder() bool {
+	return lm.accessOrder
+}
+
+// Make creates an empty map of the same type, in the same mode.
 func (lm LinkedMap[K, V]) Make() LinkedMap[K, V] {
-	return Make[K, V]()
+	result := Make[K, V]()
+	result.accessOrder = lm.accessOrder
+	return result
 }
 
-// Clone creates a shallow copy of the map.
+// Clone creates a shallow copy of the map, in the same mode.
 func (lm LinkedMap[K, V]) Clone() LinkedMap[K, V] {
-	return FromSeq(lm.Seq())
+	result := lm.Make()
+	for k, v := range lm.Seq() {
+		result.Put(k, v)
+	}
+	return result
 }
 
 // Put places a key and value pair into the map, either adding it as a new
-// entry if the key is not already in the map, or replacing an existing one.
+// entry if the key is not already in the map, or replacing an existing one. In
+// access order mode, an existing key is moved to the end of the key order.
 func (lm LinkedMap[K, V]) Put(k K, v V) {
 	if current, ok := lm.kv[k]; ok {
 		current.value = v
 		lm.kv[k] = current
+		if lm.accessOrder {
+			lm.keys.MoveToBack(current.node)
+		}
 	} else {
 		lm.keys.Append(k)
 		lm.kv[k] = makeValue(lm.keys.Last(), v)
@@ -127,16 +154,30 @@ func (lm LinkedMap[K, V]) Put(k K, v V) {
 }
 
 // Get returns the value in the map stored for key k. If key k is not present,
-// the zero value of type V is returned.
+// the zero value of type V is returned. In access order mode, the key is moved
+// to the end of the key order.
 func (lm LinkedMap[K, V]) Get(k K) V {
-	return lm.kv[k].value
+	v, _ := lm.GetOk(k)
+	return v
 }
 
 // Get returns the value in the map stored for key k along with an indicator
 // flag. If k is present in the map the associated value is returned along with
 // a true flag value. Otherwise if key k is not present, the zero value of type
-// V is returned along with a false flag value.
+// V is returned along with a false flag value. In access order mode, the key
+// is moved to the end of the key order.
 func (lm LinkedMap[K, V]) GetOk(k K) (V, bool) {
+	val, ok := lm.kv[k]
+	if ok && lm.accessOrder {
+		lm.keys.MoveToBack(val.node)
+	}
+	return val.value, ok
+}
+
+// Peek returns the value in the map stored for key k along with an indicator
+// flag, as GetOk does, but without changing the key order in access order
+// mode.
+func (lm LinkedMap[K, V]) Peek(k K) (V, bool) {
 	val, ok := lm.kv[k]
 	return val.value, ok
 }
@@ -168,7 +209,7 @@ func (lm LinkedMap[K, V]) Seq2() iter.Seq2[K, V] {
 func (lm LinkedMap[K, V]) Seq() iter.Seq2[K, V] {
 	return func(yield func(K, V) bool) {
 		for key := range lm.SeqKeys() {
-			if !yield(key, lm.Get(key)) {
+			if !yield(key, lm.kv[key].value) {
 				break
 			}
 		}
@@ -192,8 +233,19 @@ func (lm LinkedMap[K, V]) IterKeys() iterator.Iterator[K] {
 }
 
 // Iter returns an [iterator.Iterator2][K,V] over the key-value pairs in the map.
+// The iterator is double ended, and may be converted to an
+// [iterator.DoubleEndedIterator2] to consume pairs from the end of the map as
+// well as the start.
 func (lm LinkedMap[K, V]) Iter() iterator.Iterator2[K, V] {
-	return iterator.New2(lm.Seq())
+	return iterator.NewDefaultDoubleEndedIterator2[K, V](&entryIter[K, V]{lm: lm})
+}
+
+// IterMut returns an [iterator.MutableIterator2][K,V] over the key-value pairs
+// in the map. The value associated with the current key may be modified with
+// Set(), or the pair removed from the map with Delete(), without disturbing
+// the iteration.
+func (lm LinkedMap[K, V]) IterMut() iterator.MutableIterator2[K, V] {
+	return iterator.NewDefaultMutableIterator2[K, V](mutEntryIter[K, V]{&entryIter[K, V]{lm: lm}})
 }
 
 // IterKeys returns an [iterator.Iterator][V] over the values in the map.
@@ -1,6 +1,7 @@
 package lmap_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 
@@ -10,6 +11,8 @@ import (
 	"github.com/robdavid/genutil-go/lmap"
 	"github.com/robdavid/genutil-go/slices"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
 )
 
 var stringKeys = []string{"zero", "one", "two", "three", "four", "five"}
@@ -152,3 +155,201 @@ func Benchmark(b *testing.B) {
 		}
 	}
 }
+
+func TestGroupBy(t *testing.T) {
+	words := []string{"pear", "apple", "plum", "avocado", "banana", "peach"}
+	groups := lmap.GroupBy(slices.Iter(words), func(w string) byte { return w[0] })
+	assert.Equal(t, []byte{'p', 'a', 'b'}, groups.IterKeys().Collect())
+	assert.Equal(t, []string{"pear", "plum", "peach"}, groups.Get('p'))
+	assert.Equal(t, []string{"apple", "avocado"}, groups.Get('a'))
+	assert.Equal(t, []string{"banana"}, groups.Get('b'))
+}
+
+func TestGroupByNext(t *testing.T) {
+	words := []string{"pear", "apple", "plum", "avocado", "banana", "peach"}
+	gen := iterator.Generate(func(c iterator.Consumer[string]) {
+		for _, w := range words {
+			c.Yield(w)
+		}
+	})
+	assert.False(t, gen.SeqOK())
+	groups := lmap.GroupBy(gen, func(w string) byte { return w[0] })
+	assert.Equal(t, "lmap[112:[pear plum peach] 97:[apple avocado] 98:[banana]]", fmt.Sprint(groups))
+}
+
+func TestCountBy(t *testing.T) {
+	words := []string{"pear", "apple", "plum", "avocado", "banana", "peach"}
+	counts := lmap.CountBy(slices.Iter(words), func(w string) int { return len(w) })
+	assert.Equal(t, "lmap[4:2 5:2 7:1 6:1]", counts.String())
+}
+
+func TestGroupByInfinite(t *testing.T) {
+	infinite := iterator.NewWithSize(func(func(int) bool) {}, iterator.NewSizeInfinite)
+	assert.PanicsWithError(t, iterator.ErrSizeInfinite.Error(), func() {
+		lmap.GroupBy(infinite, functions.Id)
+	})
+}
+
+func TestIterDoubleEnded(t *testing.T) {
+	keys := []string{"zero", "one", "two", "three", "four", "five"}
+	lm := lmap.FromKeys(keys, func(k string) int { return len(k) })
+	itr, ok := lm.Iter().(iterator.DoubleEndedIterator2[string, int])
+	assert.True(t, ok)
+	assert.True(t, itr.NextBack())
+	assert.Equal(t, "five", itr.Key())
+	assert.Equal(t, 4, itr.Value())
+	assert.True(t, itr.Next())
+	assert.Equal(t, "zero", itr.Key())
+	assert.True(t, itr.Size().IsKnownToBe(4))
+	itr.Reset()
+	var rev []string
+	for k := range iterator.Rev2(itr).Seq2() {
+		rev = append(rev, k)
+	}
+	assert.Equal(t, []string{"five", "four", "three", "two", "one", "zero"}, rev)
+}
+
+func TestIterSeqOK(t *testing.T) {
+	lm := lmap.FromKeys([]string{"one", "two"}, func(k string) int { return len(k) })
+	itr := lm.Iter()
+	assert.True(t, itr.SeqOK())
+	assert.True(t, itr.Size().IsKnownToBe(2))
+	// Asking for the size does not fix the bounds of the iteration
+	lm.Put("three", 5)
+	assert.True(t, itr.Size().IsKnownToBe(3))
+	var keys []string
+	for k := range itr.Seq2() {
+		keys = append(keys, k)
+	}
+	assert.Equal(t, []string{"one", "two", "three"}, keys)
+	itr.Reset()
+	rev := itr.(iterator.DoubleEndedIterator2[string, int])
+	assert.True(t, rev.NextBack())
+	assert.False(t, rev.SeqOK())
+	assert.Equal(t, []int{3, 3}, rev.Collect())
+}
+
+func TestIterMut(t *testing.T) {
+	keys := []string{"zero", "one", "two", "three", "four", "five"}
+	lm := lmap.FromKeys(keys, func(k string) int { return len(k) })
+	itr := lm.IterMut()
+	for itr.Next() {
+		if itr.Value() == 3 {
+			itr.Delete()
+		} else {
+			itr.Set(itr.Value() * 10)
+			assert.Equal(t, lm.Get(itr.Key()), itr.Value())
+		}
+	}
+	assert.Equal(t, []string{"zero", "three", "four", "five"}, lm.IterKeys().Collect())
+	assert.Equal(t, []int{40, 50, 40, 40}, lm.IterValues().Collect())
+	assert.Equal(t, 4, lm.Len())
+	_, ok := lm.GetOk("one")
+	assert.False(t, ok)
+	lm.Put("six", 3)
+	assert.Equal(t, []string{"zero", "three", "four", "five", "six"}, lm.IterKeys().Collect())
+}
+
+func TestAccessOrder(t *testing.T) {
+	lm := lmap.MakeAccessOrder[string, int]()
+	assert.True(t, lm.IsAccessOrder())
+	lm.Put("a", 1)
+	lm.Put("b", 2)
+	lm.Put("c", 3)
+	assert.Equal(t, 1, lm.Get("a"))
+	assert.Equal(t, []string{"b", "c", "a"}, lm.IterKeys().Collect())
+	_, ok := lm.GetOk("b")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"c", "a", "b"}, lm.IterKeys().Collect())
+	v, ok := lm.Peek("c")
+	assert.True(t, ok)
+	assert.Equal(t, 3, v)
+	lm.Put("c", 30)
+	assert.Equal(t, []string{"a", "b", "c"}, lm.IterKeys().Collect())
+	assert.Equal(t, "lmap[a:1 b:2 c:30]", lm.String())
+	assert.Equal(t, []string{"a", "b", "c"}, lm.IterKeys().Collect())
+	clone := lm.Clone()
+	assert.True(t, clone.IsAccessOrder())
+	clone.Get("a")
+	assert.Equal(t, []string{"b", "c", "a"}, clone.IterKeys().Collect())
+	assert.Equal(t, []string{"a", "b", "c"}, lm.IterKeys().Collect())
+	assert.False(t, lmap.Make[string, int]().IsAccessOrder())
+}
+
+func TestMarshalJSON(t *testing.T) {
+	lm := lmap.FromKeys([]string{"zebra", "apple", "mango"}, func(k string) int { return len(k) })
+	data, err := json.Marshal(lm)
+	require.NoError(t, err)
+	assert.Equal(t, `{"zebra":5,"apple":5,"mango":5}`, string(data))
+	var decoded lmap.LinkedMap[string, int]
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, []string{"zebra", "apple", "mango"}, decoded.IterKeys().Collect())
+	assert.Equal(t, 5, decoded.Get("apple"))
+
+	ints := lmap.FromKeys([]int{3, 1, 2}, func(k int) string { return fmt.Sprint(k * 10) })
+	data, err = json.Marshal(ints)
+	require.NoError(t, err)
+	assert.Equal(t, `{"3":"30","1":"10","2":"20"}`, string(data))
+	var decodedInts lmap.LinkedMap[int, string]
+	require.NoError(t, json.Unmarshal(data, &decodedInts))
+	assert.Equal(t, []int{3, 1, 2}, decodedInts.IterKeys().Collect())
+
+	var zero lmap.LinkedMap[string, int]
+	data, err = json.Marshal(zero)
+	require.NoError(t, err)
+	assert.Equal(t, "null", string(data))
+	data, err = json.Marshal(lmap.Make[string, int]())
+	require.NoError(t, err)
+	assert.Equal(t, "{}", string(data))
+	require.NoError(t, json.Unmarshal([]byte("null"), &decoded))
+	assert.True(t, decoded.IsNil())
+	assert.ErrorIs(t, json.Unmarshal([]byte("[1]"), &decoded), lmap.ErrNotObject)
+	assert.Error(t, json.Unmarshal([]byte(`{"a":"b"}`), &decoded))
+}
+
+func TestMarshalJSONNested(t *testing.T) {
+	type doc struct {
+		Name   string                      `json:"name"`
+		Fields lmap.LinkedMap[string, any] `json:"fields"`
+	}
+	input := `{"name":"n","fields":{"z":1,"a":{"y":true,"b":null},"m":[{"q":1,"p":2}]}}`
+	var d doc
+	require.NoError(t, json.Unmarshal([]byte(input), &d))
+	assert.Equal(t, []string{"z", "a", "m"}, d.Fields.IterKeys().Collect())
+	assert.IsType(t, map[string]any{}, d.Fields.Get("a"))
+
+	fields, err := lmap.FromJSON[string, any]([]byte(`{"z":1,"a":{"y":true,"b":null},"m":[{"q":1,"p":2}]}`), lmap.NestedMaps(true))
+	require.NoError(t, err)
+	nested, ok := fields.Get("a").(lmap.LinkedMap[string, any])
+	require.True(t, ok)
+	assert.Equal(t, []string{"y", "b"}, nested.IterKeys().Collect())
+	inArray := fields.Get("m").([]any)[0].(lmap.LinkedMap[string, any])
+	assert.Equal(t, []string{"q", "p"}, inArray.IterKeys().Collect())
+	d.Fields = fields
+	data, err := json.Marshal(d)
+	require.NoError(t, err)
+	assert.Equal(t, input, string(data))
+}
+
+func TestMarshalYAML(t *testing.T) {
+	lm := lmap.FromKeys([]string{"zebra", "apple", "mango"}, func(k string) int { return len(k) })
+	data, err := yaml.Marshal(lm)
+	require.NoError(t, err)
+	assert.Equal(t, "zebra: 5\napple: 5\nmango: 5\n", string(data))
+	var decoded lmap.LinkedMap[string, int]
+	require.NoError(t, yaml.Unmarshal(data, &decoded))
+	assert.Equal(t, []string{"zebra", "apple", "mango"}, decoded.IterKeys().Collect())
+	assert.Equal(t, 5, decoded.Get("apple"))
+
+	var ints lmap.LinkedMap[int, string]
+	require.NoError(t, yaml.Unmarshal([]byte("3: c\n1: a\n2: b\n"), &ints))
+	assert.Equal(t, []int{3, 1, 2}, ints.IterKeys().Collect())
+	assert.Equal(t, "a", ints.Get(1))
+
+	var zero lmap.LinkedMap[string, int]
+	data, err = yaml.Marshal(map[string]any{"m": zero})
+	require.NoError(t, err)
+	assert.Equal(t, "m: null\n", string(data))
+	assert.Error(t, yaml.Unmarshal([]byte("- 1"), &decoded))
+	assert.Error(t, yaml.Unmarshal([]byte("a: b"), &decoded))
+}
@@ -0,0 +1,154 @@
+// Package yamlv3 provides a specialized wrapper type for lmap.LinkedMap[K, V]
+// which can be used with gopkg.in/yaml.v3 for marshaling and unmarshaling.
+package yamlv3
+
+import (
+	"fmt"
+
+	"github.com/robdavid/genutil-go/internal/decodeoptions"
+	"github.com/robdavid/genutil-go/internal/typehelper"
+	"github.com/robdavid/genutil-go/lmap"
+	"gopkg.in/yaml.v3"
+)
+
+// LinkedMap wraps an [lmap.LinkedMap][K, V]. This wrapper provides all the
+// methods of the wrapped map, along with marshaling and unmarshaling logic
+// tailored for YAML v3, preserving the order of the keys of the map.
+type LinkedMap[K comparable, V any] struct {
+	lmap.LinkedMap[K, V]
+}
+
+// From creates a LinkedMap wrapping an existing [lmap.LinkedMap].
+func From[K comparable, V any](lm lmap.LinkedMap[K, V]) LinkedMap[K, V] {
+	return LinkedMap[K, V]{lm}
+}
+
+// Make creates a new, empty LinkedMap, as [lmap.Make] does.
+func Make[K comparable, V any]() LinkedMap[K, V] {
+	return LinkedMap[K, V]{lmap.Make[K, V]()}
+}
+
+// MarshalYAML implements YAML marshaling of a LinkedMap for the
+// https://pkg.go.dev/gopkg.in/yaml.v3 YAML parser. The map is marshaled as a
+// YAML mapping, with its entries in the same order as the keys of the map. A
+// nil map is marshaled as null.
+func (lm LinkedMap[K, V]) MarshalYAML() (any, error) {
+	if lm.IsNil() {
+		return nil, nil
+	}
+	node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	for k, v := range lm.Seq() {
+		var keyNode, valueNode yaml.Node
+		if err := keyNode.Encode(k); err != nil {
+			return nil, err
+		}
+		if err := valueNode.Encode(v); err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, &keyNode, &valueNode)
+	}
+	return node, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler for LinkedMap, decoding a YAML
+// mapping node into the map and replacing any existing contents. The keys of
+// the map are placed in the order in which they appear in the mapping. A null
+// node decodes as a nil map. Use [FromYAML] to decode nested mappings as
+// LinkedMaps too.
+func (lm *LinkedMap[K, V]) UnmarshalYAML(node *yaml.Node) error {
+	result := lm.LinkedMap.Make()
+	if err := decodeYAML(node, &result, decodeoptions.Options{}); err != nil {
+		return err
+	}
+	lm.LinkedMap = result
+	return nil
+}
+
+// FromYAML creates a new LinkedMap instance from a YAML node, as
+// [LinkedMap.UnmarshalYAML] does, with the provided options. Nested mappings
+// decoded with [lmap.NestedMaps] are of type LinkedMap[string, any], so that
+// they marshal as YAML mappings too.
+func FromYAML[K comparable, V any](node *yaml.Node, opts ...lmap.DecodeOption) (LinkedMap[K, V], error) {
+	result := lmap.Make[K, V]()
+	err := decodeYAML(node, &result, decodeoptions.Combine(opts))
+	return LinkedMap[K, V]{result}, err
+}
+
+// resolveYAML finds the node holding the content of document and alias nodes.
+func resolveYAML(node *yaml.Node) *yaml.Node {
+	for {
+		switch {
+		case node.Kind == yaml.DocumentNode && len(node.Content) == 1:
+			node = node.Content[0]
+		case node.Kind == yaml.AliasNode:
+			node = node.Alias
+		default:
+			return node
+		}
+	}
+}
+
+func decodeYAML[K comparable, V any](node *yaml.Node, result *lmap.LinkedMap[K, V], o decodeoptions.Options) error {
+	node = resolveYAML(node)
+	if node.Kind == yaml.ScalarNode && node.ShortTag() == "!!null" {
+		*result = lmap.LinkedMap[K, V]{}
+		return nil
+	}
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("%w: YAML %s at line %d", lmap.ErrNotObject, node.ShortTag(), node.Line)
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		var k K
+		if err := node.Content[i].Decode(&k); err != nil {
+			return err
+		}
+		var v V
+		if o.Nested && typehelper.IsAny[V]() {
+			nested, err := decodeYAMLAny(node.Content[i+1])
+			if err != nil {
+				return err
+			}
+			v, _ = nested.(V)
+		} else if err := node.Content[i+1].Decode(&v); err != nil {
+			return err
+		}
+		result.Put(k, v)
+	}
+	return nil
+}
+
+// decodeYAMLAny decodes a YAML node as yaml.v3 does into a value of type any,
+// except that mappings are decoded as LinkedMap[string, any].
+func decodeYAMLAny(node *yaml.Node) (any, error) {
+	node = resolveYAML(node)
+	switch node.Kind {
+	case yaml.MappingNode:
+		result := Make[string, any]()
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			var key string
+			if err := node.Content[i].Decode(&key); err != nil {
+				return nil, err
+			}
+			value, err := decodeYAMLAny(node.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+			result.Put(key, value)
+		}
+		return result, nil
+	case yaml.SequenceNode:
+		result := make([]any, 0, len(node.Content))
+		for _, item := range node.Content {
+			value, err := decodeYAMLAny(item)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, value)
+		}
+		return result, nil
+	default:
+		var result any
+		err := node.Decode(&result)
+		return result, err
+	}
+}
@@ -0,0 +1,52 @@
+package yamlv3_test
+
+import (
+	"testing"
+
+	"github.com/robdavid/genutil-go/lmap"
+	"github.com/robdavid/genutil-go/lmap/yamlv3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestMarshalYAML(t *testing.T) {
+	lm := yamlv3.From(lmap.FromKeys([]string{"zebra", "apple", "mango"}, func(k string) int { return len(k) }))
+	data, err := yaml.Marshal(lm)
+	require.NoError(t, err)
+	assert.Equal(t, "zebra: 5\napple: 5\nmango: 5\n", string(data))
+	var decoded yamlv3.LinkedMap[string, int]
+	require.NoError(t, yaml.Unmarshal(data, &decoded))
+	assert.Equal(t, []string{"zebra", "apple", "mango"}, decoded.IterKeys().Collect())
+	var zero yamlv3.LinkedMap[string, int]
+	data, err = yaml.Marshal(map[string]any{"m": zero})
+	require.NoError(t, err)
+	assert.Equal(t, "m: null\n", string(data))
+	var node yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte("~"), &node))
+	decoded, err = yamlv3.FromYAML[string, int](&node)
+	require.NoError(t, err)
+	assert.True(t, decoded.IsNil())
+	assert.ErrorIs(t, yaml.Unmarshal([]byte("- 1"), &decoded), lmap.ErrNotObject)
+}
+
+func TestMarshalYAMLNested(t *testing.T) {
+	input := "z: 1\na: &anchor\n    x: true\n    b: null\nm:\n    - q: 1\n      p: 2\nc: *anchor\n"
+	var node yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(input), &node))
+	lm, err := yamlv3.FromYAML[string, any](&node, lmap.NestedMaps(true))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"z", "a", "m", "c"}, lm.IterKeys().Collect())
+	nested, ok := lm.Get("c").(yamlv3.LinkedMap[string, any])
+	require.True(t, ok)
+	assert.Equal(t, []string{"x", "b"}, nested.IterKeys().Collect())
+	inArray := lm.Get("m").([]any)[0].(yamlv3.LinkedMap[string, any])
+	assert.Equal(t, []string{"q", "p"}, inArray.IterKeys().Collect())
+	data, err := yaml.Marshal(lm)
+	require.NoError(t, err)
+	assert.Equal(t, "z: 1\na:\n    x: true\n    b: null\nm:\n    - q: 1\n      p: 2\nc:\n    x: true\n    b: null\n", string(data))
+
+	var flat yamlv3.LinkedMap[string, any]
+	require.NoError(t, node.Decode(&flat))
+	assert.IsType(t, map[string]any{}, flat.Get("a"))
+}
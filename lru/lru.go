@@ -0,0 +1,200 @@
+// Package lru provides a least recently used cache, built on an access ordered
+// [lmap.LinkedMap].
+package lru
+
+import (
+	"sync"
+
+	"github.com/robdavid/genutil-go/lmap"
+)
+
+// entry is a cached value along with its cost.
+type entry[V any] struct {
+	value V
+	cost  int
+}
+
+// Option is a function that configures a [Cache] when it is created.
+type Option[K comparable, V any] func(*Cache[K, V])
+
+// MaxCost is an option that limits the total cost of the entries in a cache.
+// The cost of each entry is calculated by the cost function when it is put
+// into the cache. Once the total cost exceeds maxCost, the least recently used
+// entries are evicted until it no longer does.
+func MaxCost[K comparable, V any](maxCost int, cost func(K, V) int) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.maxCost = maxCost
+		c.cost = cost
+	}
+}
+
+// OnEvict is an option that sets a function to be called with the key and
+// value of each entry evicted from a cache to satisfy its limits. It is not
+// called for entries that are removed explicitly, or whose values are
+// replaced.
+func OnEvict[K comparable, V any](f func(K, V)) Option[K, V] {
+	return func(c *Cache[K, V]) { c.onEvict = f }
+}
+
+// Cache is a cache of key and value pairs of limited size. When a limit is
+// exceeded, the least recently used entries are evicted. An entry is used
+// when it is put into the cache, or read from it by Get. A Cache is not safe
+// for concurrent use; see [SyncCache].
+type Cache[K comparable, V any] struct {
+	entries    lmap.LinkedMap[K, entry[V]]
+	maxEntries int
+	maxCost    int
+	totalCost  int
+	cost       func(K, V) int
+	onEvict    func(K, V)
+}
+
+// New creates a cache that holds at most maxEntries entries. If maxEntries is
+// zero or less, the number of entries is not limited, which is useful when a
+// limit is set by the [MaxCost] option instead.
+func New[K comparable, V any](maxEntries int, opts ...Option[K, V]) *Cache[K, V] {
+	c := &Cache[K, V]{entries: lmap.MakeAccessOrder[K, entry[V]](), maxEntries: maxEntries}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// overLimit returns true if the cache exceeds any of its limits.
+func (c *Cache[K, V]) overLimit() bool {
+	return (c.maxEntries > 0 && c.entries.Len() > c.maxEntries) ||
+		(c.cost != nil && c.totalCost > c.maxCost)
+}
+
+// evict removes the least recently used entries until the cache is within
+// its limits.
+func (c *Cache[K, V]) evict() {
+	for !c.entries.IsEmpty() && c.overLimit() {
+		k := c.entries.KeyAt(0)
+		e, _ := c.entries.Delete(k)
+		c.totalCost -= e.cost
+		if c.onEvict != nil {
+			c.onEvict(k, e.value)
+		}
+	}
+}
+
+// Put adds a key and value to the cache, or replaces the value of an existing
+// key, making it the most recently used entry. Other entries are then evicted
+// if necessary. If the cost of this entry alone exceeds the maximum cost, it
+// is evicted too.
+func (c *Cache[K, V]) Put(k K, v V) {
+	cost := 0
+	if c.cost != nil {
+		cost = c.cost(k, v)
+	}
+	if old, ok := c.entries.Peek(k); ok {
+		c.totalCost -= old.cost
+	}
+	c.entries.Put(k, entry[V]{v, cost})
+	c.totalCost += cost
+	c.evict()
+}
+
+// Get returns the value cached for a key, and whether it was present. The
+// entry becomes the most recently used.
+func (c *Cache[K, V]) Get(k K) (V, bool) {
+	e, ok := c.entries.GetOk(k)
+	return e.value, ok
+}
+
+// Peek returns the value cached for a key, and whether it was present,
+// without affecting how recently the entry was used.
+func (c *Cache[K, V]) Peek(k K) (V, bool) {
+	e, ok := c.entries.Peek(k)
+	return e.value, ok
+}
+
+// Remove removes a key from the cache, returning its value and whether it was
+// present.
+func (c *Cache[K, V]) Remove(k K) (V, bool) {
+	e, ok := c.entries.Delete(k)
+	c.totalCost -= e.cost
+	return e.value, ok
+}
+
+// Len returns the number of entries in the cache.
+func (c *Cache[K, V]) Len() int {
+	return c.entries.Len()
+}
+
+// Cost returns the total cost of the entries in the cache. It is always zero
+// unless the [MaxCost] option was used.
+func (c *Cache[K, V]) Cost() int {
+	return c.totalCost
+}
+
+// Keys returns the keys in the cache, from the least to the most recently
+// used.
+func (c *Cache[K, V]) Keys() []K {
+	return c.entries.IterKeys().Collect()
+}
+
+// SyncCache is a [Cache] that is safe for concurrent use, guarding every
+// operation with a mutex. The OnEvict function is called while the mutex is
+// held, so it must not call back into the cache.
+type SyncCache[K comparable, V any] struct {
+	mutex sync.Mutex
+	cache *Cache[K, V]
+}
+
+// NewSync creates a cache that is safe for concurrent use, with the same
+// limits and options as [New].
+func NewSync[K comparable, V any](maxEntries int, opts ...Option[K, V]) *SyncCache[K, V] {
+	return &SyncCache[K, V]{cache: New(maxEntries, opts...)}
+}
+
+// Put adds a key and value to the cache, as [Cache.Put] does.
+func (sc *SyncCache[K, V]) Put(k K, v V) {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	sc.cache.Put(k, v)
+}
+
+// Get returns the value cached for a key, as [Cache.Get] does.
+func (sc *SyncCache[K, V]) Get(k K) (V, bool) {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	return sc.cache.Get(k)
+}
+
+// Peek returns the value cached for a key, as [Cache.Peek] does.
+func (sc *SyncCache[K, V]) Peek(k K) (V, bool) {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	return sc.cache.Peek(k)
+}
+
+// Remove removes a key from the cache, as [Cache.Remove] does.
+func (sc *SyncCache[K, V]) Remove(k K) (V, bool) {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	return sc.cache.Remove(k)
+}
+
+// Len returns the number of entries in the cache.
+func (sc *SyncCache[K, V]) Len() int {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	return sc.cache.Len()
+}
+
+// Cost returns the total cost of the entries in the cache.
+func (sc *SyncCache[K, V]) Cost() int {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	return sc.cache.Cost()
+}
+
+// Keys returns the keys in the cache, from the least to the most recently
+// used.
+func (sc *SyncCache[K, V]) Keys() []K {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	return sc.cache.Keys()
+}
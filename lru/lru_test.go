@@ -0,0 +1,100 @@
+package lru_test
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/robdavid/genutil-go/lru"
+	"github.com/stretchr/testify/assert"
+)
+
+type eviction struct {
+	key   string
+	value int
+}
+
+func TestEvictionOrder(t *testing.T) {
+	var evicted []eviction
+	cache := lru.New(3, lru.OnEvict(func(k string, v int) { evicted = append(evicted, eviction{k, v}) }))
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+	assert.Empty(t, evicted)
+	v, ok := cache.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+	cache.Put("d", 4)
+	assert.Equal(t, []eviction{{"b", 2}}, evicted)
+	assert.Equal(t, []string{"c", "a", "d"}, cache.Keys())
+	cache.Put("c", 30)
+	cache.Put("e", 5)
+	cache.Put("f", 6)
+	assert.Equal(t, []eviction{{"b", 2}, {"a", 1}, {"d", 4}}, evicted)
+	assert.Equal(t, []string{"c", "e", "f"}, cache.Keys())
+	_, ok = cache.Get("a")
+	assert.False(t, ok)
+}
+
+func TestPeekAndRemove(t *testing.T) {
+	var evicted []string
+	cache := lru.New(2, lru.OnEvict(func(k string, _ int) { evicted = append(evicted, k) }))
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	v, ok := cache.Peek("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+	cache.Put("c", 3)
+	assert.Equal(t, []string{"a"}, evicted)
+	v, ok = cache.Remove("b")
+	assert.True(t, ok)
+	assert.Equal(t, 2, v)
+	_, ok = cache.Remove("b")
+	assert.False(t, ok)
+	assert.Equal(t, []string{"a"}, evicted)
+	assert.Equal(t, 1, cache.Len())
+}
+
+func TestMaxCost(t *testing.T) {
+	var evicted []string
+	cache := lru.New(0,
+		lru.MaxCost(10, func(_ string, v string) int { return len(v) }),
+		lru.OnEvict(func(k string, _ string) { evicted = append(evicted, k) }))
+	cache.Put("a", "xxxx")
+	cache.Put("b", "xxxx")
+	assert.Equal(t, 8, cache.Cost())
+	cache.Get("a")
+	cache.Put("c", "xxx")
+	assert.Equal(t, []string{"b"}, evicted)
+	assert.Equal(t, 7, cache.Cost())
+	cache.Put("a", "x")
+	assert.Equal(t, 4, cache.Cost())
+	cache.Put("d", "xxxxxxxxxxxx")
+	assert.Equal(t, []string{"b", "c", "a", "d"}, evicted)
+	assert.Zero(t, cache.Len())
+	assert.Zero(t, cache.Cost())
+	for i := range 20 {
+		cache.Put(strconv.Itoa(i), "x")
+	}
+	assert.Equal(t, 10, cache.Len())
+}
+
+func TestSyncCache(t *testing.T) {
+	var evictions int
+	cache := lru.NewSync(100, lru.OnEvict(func(int, int) { evictions++ }))
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 1000 {
+				cache.Put(g*1000+i, i)
+				cache.Get(g*1000 + i/2)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 100, cache.Len())
+	assert.Equal(t, 8000-100, evictions)
+	assert.Len(t, cache.Keys(), 100)
+}
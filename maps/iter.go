@@ -0,0 +1,198 @@
+package maps
+
+import (
+	"iter"
+
+	"github.com/robdavid/genutil-go/iterator"
+)
+
+// mapIter is an iterator over the entries of a map. Normally it ranges over
+// the map directly. If TrySplit is called before iteration has started, the
+// keys are first collected into a keyIter, so that they can be divided
+// between iterators, and the keyIter is used from then on. An iterator split
+// from another one has only a keyIter.
+type mapIter[K comparable, T any] struct {
+	*iterator.SeqCoreIterator2[K, T]
+	m       map[K]T
+	keys    *keyIter[K, T]
+	started bool
+}
+
+func newMapIter[K comparable, T any](m map[K]T) *mapIter[K, T] {
+	size := len(m)
+	core := iterator.NewSeqCoreIterator2WithSize(
+		func(yield func(K, T) bool) {
+			size = len(m)
+			for k, v := range m {
+				size--
+				if !yield(k, v) {
+					break
+				}
+			}
+		},
+		func() iterator.IteratorSize {
+			return iterator.NewSize(size)
+		},
+	)
+	return &mapIter[K, T]{SeqCoreIterator2: core, m: m}
+}
+
+func (mi *mapIter[K, T]) Next() bool {
+	if mi.keys != nil {
+		return mi.keys.Next()
+	}
+	mi.started = true
+	return mi.SeqCoreIterator2.Next()
+}
+
+func (mi *mapIter[K, T]) Key() K {
+	if mi.keys != nil {
+		return mi.keys.key
+	}
+	return mi.SeqCoreIterator2.Key()
+}
+
+func (mi *mapIter[K, T]) Value() T {
+	if mi.keys != nil {
+		return mi.keys.value
+	}
+	return mi.SeqCoreIterator2.Value()
+}
+
+func (mi *mapIter[K, T]) Abort() {
+	if mi.keys != nil {
+		mi.keys.done = true
+	} else {
+		mi.SeqCoreIterator2.Abort()
+	}
+}
+
+// Reset restarts the iterator. Once keys have been collected by TrySplit, the
+// iterator restarts from the first of the keys that remain to it, so that
+// entries handed to an iterator split from it are not visited again;
+// otherwise, the map is ranged over afresh.
+func (mi *mapIter[K, T]) Reset() {
+	if mi.keys != nil {
+		mi.keys.reset()
+		return
+	}
+	mi.started = false
+	mi.SeqCoreIterator2.Reset()
+}
+
+func (mi *mapIter[K, T]) Size() iterator.IteratorSize {
+	if mi.keys != nil {
+		return mi.keys.size()
+	}
+	return mi.SeqCoreIterator2.Size()
+}
+
+func (mi *mapIter[K, T]) SeqOK() bool {
+	return mi.keys == nil && mi.SeqCoreIterator2.SeqOK()
+}
+
+func (mi *mapIter[K, T]) Seq() iter.Seq[T] {
+	if mi.keys != nil {
+		return iterator.Seq(mi)
+	}
+	mi.started = true
+	return mi.SeqCoreIterator2.Seq()
+}
+
+func (mi *mapIter[K, T]) Seq2() iter.Seq2[K, T] {
+	if mi.keys == nil {
+		mi.started = true
+		return mi.SeqCoreIterator2.Seq2()
+	}
+	return func(yield func(K, T) bool) {
+		for mi.Next() {
+			if !yield(mi.keys.key, mi.keys.value) {
+				mi.Abort()
+				break
+			}
+		}
+	}
+}
+
+// TrySplit divides the remaining keys in two, returning an iterator over the
+// entries with the first half of them. A map cannot be split once iteration
+// over it has started.
+func (mi *mapIter[K, T]) TrySplit() (iterator.CoreIterator[T], bool) {
+	if mi.keys == nil {
+		if mi.started {
+			return nil, false
+		}
+		mi.keys = &keyIter[K, T]{m: mi.m, keys: Keys(mi.m)}
+	}
+	prefix, ok := mi.keys.split()
+	if !ok {
+		return nil, false
+	}
+	return newMapIterator(&mapIter[K, T]{keys: prefix}), true
+}
+
+// keyIter iterates over the entries of a map with the keys in a slice,
+// skipping any that have been deleted from the map.
+type keyIter[K comparable, T any] struct {
+	m     map[K]T
+	keys  []K
+	index int
+	done  bool
+	key   K
+	value T
+}
+
+func (ki *keyIter[K, T]) Next() bool {
+	if ki.done {
+		return false
+	}
+	for ki.index < len(ki.keys) {
+		k := ki.keys[ki.index]
+		ki.index++
+		if v, ok := ki.m[k]; ok {
+			ki.key, ki.value = k, v
+			return true
+		}
+	}
+	return false
+}
+
+func (ki *keyIter[K, T]) reset() {
+	ki.index = 0
+	ki.done = false
+}
+
+func (ki *keyIter[K, T]) size() iterator.IteratorSize {
+	if ki.done {
+		return iterator.NewSize(0)
+	}
+	return iterator.NewSize(len(ki.keys) - ki.index)
+}
+
+// split removes the first half of the remaining keys, returning them in a new
+// keyIter.
+func (ki *keyIter[K, T]) split() (*keyIter[K, T], bool) {
+	n := len(ki.keys) - ki.index
+	if ki.done || n < 2 {
+		return nil, false
+	}
+	mid := ki.index + n/2
+	prefix := &keyIter[K, T]{m: ki.m, keys: ki.keys[ki.index:mid:mid]}
+	ki.keys, ki.index = ki.keys[mid:], 0
+	return prefix, true
+}
+
+// mapIterator adds the methods of [iterator.Iterator2] to a mapIter, while
+// exposing its TrySplit method so that it is [iterator.Splittable].
+type mapIterator[K comparable, T any] struct {
+	iterator.DefaultIterator2[K, T]
+	core *mapIter[K, T]
+}
+
+func newMapIterator[K comparable, T any](core *mapIter[K, T]) mapIterator[K, T] {
+	return mapIterator[K, T]{DefaultIterator2: iterator.NewDefaultIterator2[K, T](core), core: core}
+}
+
+func (mi mapIterator[K, T]) TrySplit() (iterator.CoreIterator[T], bool) {
+	return mi.core.TrySplit()
+}
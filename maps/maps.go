@@ -322,23 +322,11 @@ func IterItems[K comparable, T any](m map[K]T) iterator.Iterator2[K, T] {
 }
 
 // Returns an iterator over the keys and values of a map, returning each pair
-// via an iterator.Iterator2.
+// via an iterator.Iterator2. The iterator is [iterator.Splittable], so that the
+// entries may be processed in parallel by functions such as
+// [iterator.ParFold].
 func Iter[K comparable, T any](m map[K]T) iterator.Iterator2[K, T] {
-	size := len(m)
-	return iterator.New2WithSize(
-		func(yield func(K, T) bool) {
-			size = len(m)
-			for k, v := range m {
-				size--
-				if !yield(k, v) {
-					break
-				}
-			}
-		},
-		func() iterator.IteratorSize {
-			return iterator.NewSize(size)
-		},
-	)
+	return newMapIterator(newMapIter(m))
 }
 
 func IterMut[K comparable, T any](m map[K]T) iterator.MutableIterator2[K, T] {
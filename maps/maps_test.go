@@ -257,6 +257,62 @@ func TestIterSize(t *testing.T) {
 	assert.Zero(t, itr.Size().Size)
 }
 
+func TestIterTrySplit(t *testing.T) {
+	mymap := make(map[int]string)
+	const mapsize = 20
+	for n := range mapsize {
+		mymap[n] = strconv.Itoa(n)
+	}
+	itr := Iter(mymap)
+	prefix, ok := itr.(iterator.Splittable[string]).TrySplit()
+	assert.True(t, ok)
+	assert.Equal(t, 10, prefix.Size().Size)
+	assert.Equal(t, 10, itr.Size().Size)
+	items := make(map[int]string)
+	for _, part := range []iterator.CoreIterator2[int, string]{prefix.(iterator.CoreIterator2[int, string]), itr} {
+		for part.Next() {
+			items[part.Key()] = part.Value()
+		}
+	}
+	assert.Equal(t, mymap, items)
+	itr.Reset()
+	rest := iterator.CollectMap(itr)
+	assert.Len(t, rest, 10)
+	prefix.Reset()
+	for part := prefix.(iterator.CoreIterator2[int, string]); part.Next(); {
+		assert.NotContains(t, rest, part.Key())
+		rest[part.Key()] = part.Value()
+	}
+	assert.Equal(t, mymap, rest)
+}
+
+func TestIterTrySplitStarted(t *testing.T) {
+	itr := Iter(map[int]int{1: 1, 2: 2, 3: 3})
+	assert.True(t, itr.SeqOK())
+	assert.True(t, itr.Next())
+	_, ok := itr.(iterator.Splittable[int]).TrySplit()
+	assert.False(t, ok)
+	itr.Abort()
+	itr.Reset()
+	_, ok = itr.(iterator.Splittable[int]).TrySplit()
+	assert.True(t, ok)
+}
+
+func TestIterTrySplitAbort(t *testing.T) {
+	mymap := map[int]int{1: 1, 2: 2, 3: 3, 4: 4}
+	itr := Iter(mymap)
+	prefix, ok := itr.(iterator.Splittable[int]).TrySplit()
+	assert.True(t, ok)
+	part := prefix.(iterator.Iterator2[int, int])
+	assert.True(t, part.Next())
+	part.Abort()
+	assert.False(t, part.Next())
+	assert.True(t, part.Size().IsKnownToBe(0))
+	part.Reset()
+	assert.Len(t, iterator.CollectMap(part), 2)
+	assert.Len(t, iterator.CollectMap(itr), 2)
+}
+
 func TestIterSimple(t *testing.T) {
 	mymap := make(map[int]int)
 	seen := make(map[int]bool)
@@ -0,0 +1,122 @@
+// Package persistent provides immutable collection types. Operations that
+// would modify a collection instead return a new version of it, which shares
+// the unchanged parts of its structure with the original. Taking a snapshot of
+// a collection is therefore free, and each version may be safely shared
+// between goroutines without locking.
+package persistent
+
+import (
+	"iter"
+
+	"github.com/robdavid/genutil-go/iterator"
+	"github.com/robdavid/genutil-go/opt"
+)
+
+// cell is an element of a List, which records the size of the list that
+// starts with it.
+type cell[T any] struct {
+	value T
+	next  *cell[T]
+	size  int
+}
+
+// List is an immutable singly linked list. Adding an element to the front of
+// a list, or taking the list that follows the first element, produces a new
+// list sharing all the elements of the original in constant time. The zero
+// value is an empty list.
+type List[T any] struct {
+	head *cell[T]
+}
+
+// ListOf creates a new list whose elements are taken from the variadic args of
+// the function.
+func ListOf[T any](values ...T) List[T] {
+	var lst List[T]
+	for i := len(values) - 1; i >= 0; i-- {
+		lst = lst.Prepend(values[i])
+	}
+	return lst
+}
+
+// ListFromSeq creates a new list whose elements are taken, in order, from the
+// provided [iter.Seq][T] iterator.
+func ListFromSeq[T any](itr iter.Seq[T]) List[T] {
+	var values []T
+	for v := range itr {
+		values = append(values, v)
+	}
+	return ListOf(values...)
+}
+
+// ListFrom creates a new list whose elements are taken, in order, from the
+// provided [iterator.Iterator][T] iterator.
+func ListFrom[T any](itr iterator.Iterator[T]) List[T] {
+	return ListOf(itr.Collect()...)
+}
+
+// Len returns the number of elements in the list.
+func (lst List[T]) Len() int {
+	if lst.head == nil {
+		return 0
+	}
+	return lst.head.size
+}
+
+// IsEmpty returns true if the list is empty.
+func (lst List[T]) IsEmpty() bool {
+	return lst.head == nil
+}
+
+// Prepend returns a new list consisting of value followed by the elements of
+// this list.
+func (lst List[T]) Prepend(value T) List[T] {
+	return List[T]{&cell[T]{value: value, next: lst.head, size: lst.Len() + 1}}
+}
+
+// Head returns the first element of the list, or an empty value if the list
+// is empty.
+func (lst List[T]) Head() opt.Val[T] {
+	if lst.head == nil {
+		return opt.Empty[T]()
+	}
+	return opt.Value(lst.head.value)
+}
+
+// Tail returns the list of elements following the first one. The tail of an
+// empty list is empty.
+func (lst List[T]) Tail() List[T] {
+	if lst.head == nil {
+		return lst
+	}
+	return List[T]{lst.head.next}
+}
+
+// Seq returns an [iter.Seq][T] over the elements of the list.
+func (lst List[T]) Seq() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for c := lst.head; c != nil; c = c.next {
+			if !yield(c.value) {
+				return
+			}
+		}
+	}
+}
+
+// Iter returns an [iterator.Iterator][T] over the elements of the list.
+func (lst List[T]) Iter() iterator.Iterator[T] {
+	remain := lst.Len()
+	return iterator.NewWithSize(
+		func(yield func(T) bool) {
+			remain = lst.Len()
+			for c := lst.head; c != nil; c = c.next {
+				remain--
+				if !yield(c.value) {
+					break
+				}
+			}
+		},
+		func() iterator.IteratorSize {
+			return iterator.NewSize(remain)
+		},
+	)
+}
@@ -0,0 +1,272 @@
+package persistent
+
+import (
+	"hash/maphash"
+	"iter"
+	"math/bits"
+	"slices"
+
+	"github.com/robdavid/genutil-go/iterator"
+)
+
+const (
+	hashBits  = 5
+	hashMask  = 1<<hashBits - 1
+	hashWidth = 64
+)
+
+var seed = maphash.MakeSeed()
+
+// entry is a slot in a HAMT node, holding either a key and value, or a child
+// node if child is not nil.
+type entry[K comparable, V any] struct {
+	hash  uint64
+	key   K
+	value V
+	child *node[K, V]
+}
+
+// node is a node of a hash array mapped trie. Each level of the trie is
+// indexed by the next hashBits bits of the key hash, and the bitmap records
+// which of the possible slots are present in entries. Once all the bits of the
+// hash have been used, the entries of a node are keys with identical hashes,
+// and the bitmap is not used. Nodes are never modified once they are part of
+// a map.
+type node[K comparable, V any] struct {
+	bitmap  uint32
+	entries []entry[K, V]
+}
+
+// slot returns the bit for the hash in a node at the given shift, and the
+// position in entries that it corresponds to.
+func (n *node[K, V]) slot(hash uint64, shift uint) (uint32, int) {
+	bit := uint32(1) << ((hash >> shift) & hashMask)
+	return bit, bits.OnesCount32(n.bitmap & (bit - 1))
+}
+
+// replace returns a copy of the node with the entry at index i replaced.
+func (n *node[K, V]) replace(i int, e entry[K, V]) *node[K, V] {
+	entries := slices.Clone(n.entries)
+	entries[i] = e
+	return &node[K, V]{bitmap: n.bitmap, entries: entries}
+}
+
+// remove returns a copy of the node with the entry at index i, whose bit is
+// bit, removed, or nil if there would be no entries left.
+func (n *node[K, V]) remove(i int, bit uint32) *node[K, V] {
+	if len(n.entries) == 1 {
+		return nil
+	}
+	return &node[K, V]{bitmap: n.bitmap &^ bit, entries: slices.Delete(slices.Clone(n.entries), i, i+1)}
+}
+
+func (n *node[K, V]) get(hash uint64, key K) (V, bool) {
+	for shift := uint(0); n != nil; shift += hashBits {
+		if shift >= hashWidth {
+			for _, e := range n.entries {
+				if e.key == key {
+					return e.value, true
+				}
+			}
+			break
+		}
+		bit, i := n.slot(hash, shift)
+		if n.bitmap&bit == 0 {
+			break
+		}
+		e := n.entries[i]
+		if e.child == nil {
+			if e.key == key {
+				return e.value, true
+			}
+			break
+		}
+		n = e.child
+	}
+	var zero V
+	return zero, false
+}
+
+// with returns a copy of the node in which the key of e is associated with its
+// value, and whether the key was added rather than replaced. Only the nodes on
+// the path to the key are copied.
+func (n *node[K, V]) with(e entry[K, V], shift uint) (*node[K, V], bool) {
+	if shift >= hashWidth {
+		for i, c := range n.entries {
+			if c.key == e.key {
+				return n.replace(i, e), false
+			}
+		}
+		return &node[K, V]{entries: append(slices.Clip(n.entries), e)}, true
+	}
+	bit, i := n.slot(e.hash, shift)
+	if n.bitmap&bit == 0 {
+		return &node[K, V]{bitmap: n.bitmap | bit, entries: slices.Insert(slices.Clone(n.entries), i, e)}, true
+	}
+	current := n.entries[i]
+	switch {
+	case current.child != nil:
+		child, added := current.child.with(e, shift+hashBits)
+		return n.replace(i, entry[K, V]{child: child}), added
+	case current.key == e.key:
+		return n.replace(i, e), false
+	default:
+		child, _ := (&node[K, V]{}).with(current, shift+hashBits)
+		child, _ = child.with(e, shift+hashBits)
+		return n.replace(i, entry[K, V]{child: child}), true
+	}
+}
+
+// without returns a copy of the node with the key removed, or nil if the node
+// would be empty, and whether the key was present. A child node left holding a
+// single key and value is replaced by that entry.
+func (n *node[K, V]) without(hash uint64, key K, shift uint) (*node[K, V], bool) {
+	if shift >= hashWidth {
+		for i, c := range n.entries {
+			if c.key == key {
+				return n.remove(i, 0), true
+			}
+		}
+		return n, false
+	}
+	bit, i := n.slot(hash, shift)
+	if n.bitmap&bit == 0 {
+		return n, false
+	}
+	current := n.entries[i]
+	if current.child == nil {
+		if current.key != key {
+			return n, false
+		}
+		return n.remove(i, bit), true
+	}
+	child, removed := current.child.without(hash, key, shift+hashBits)
+	switch {
+	case !removed:
+		return n, false
+	case child == nil:
+		return n.remove(i, bit), true
+	case len(child.entries) == 1 && child.entries[0].child == nil:
+		return n.replace(i, child.entries[0]), true
+	default:
+		return n.replace(i, entry[K, V]{child: child}), true
+	}
+}
+
+func (n *node[K, V]) all(yield func(K, V) bool) bool {
+	for _, e := range n.entries {
+		if e.child == nil {
+			if !yield(e.key, e.value) {
+				return false
+			}
+		} else if !e.child.all(yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// Map is an immutable hash map, implemented as a hash array mapped trie.
+// Adding or removing a key produces a new map which shares most of its
+// structure with the original, taking time proportional to the logarithm of
+// the size of the map. Keys are produced in an order determined by their
+// hashes, which is consistent between maps within a process, but not between
+// processes. The zero value is an empty map.
+type Map[K comparable, V any] struct {
+	root *node[K, V]
+	size int
+}
+
+// MapFromSeq creates a new Map populated with keys and values taken from the
+// provided [iter.Seq2][K,V] iterator. Where a key occurs more than once, the
+// last value is retained.
+func MapFromSeq[K comparable, V any](itr iter.Seq2[K, V]) Map[K, V] {
+	var m Map[K, V]
+	for k, v := range itr {
+		m = m.With(k, v)
+	}
+	return m
+}
+
+// MapFrom creates a new Map populated with keys and values taken from the
+// provided [iterator.Iterator2][K,V] iterator.
+func MapFrom[K comparable, V any](itr iterator.Iterator2[K, V]) Map[K, V] {
+	return MapFromSeq(itr.Seq2())
+}
+
+// Len returns the number of keys in the map.
+func (m Map[K, V]) Len() int {
+	return m.size
+}
+
+// IsEmpty returns true if the map has no keys.
+func (m Map[K, V]) IsEmpty() bool {
+	return m.size == 0
+}
+
+// Get returns the value associated with a key, or the zero value if the key
+// is not present.
+func (m Map[K, V]) Get(k K) V {
+	v, _ := m.GetOk(k)
+	return v
+}
+
+// GetOk returns the value associated with a key, and whether the key was
+// present.
+func (m Map[K, V]) GetOk(k K) (V, bool) {
+	return m.root.get(maphash.Comparable(seed, k), k)
+}
+
+// With returns a new map in which k is associated with v, in addition to the
+// other keys and values of this map, which is unchanged.
+func (m Map[K, V]) With(k K, v V) Map[K, V] {
+	root := m.root
+	if root == nil {
+		root = &node[K, V]{}
+	}
+	root, added := root.with(entry[K, V]{hash: maphash.Comparable(seed, k), key: k, value: v}, 0)
+	if added {
+		return Map[K, V]{root, m.size + 1}
+	}
+	return Map[K, V]{root, m.size}
+}
+
+// Without returns a new map with the same keys and values as this map, except
+// for k. If k is not present, the map returned is this one.
+func (m Map[K, V]) Without(k K) Map[K, V] {
+	if m.root == nil {
+		return m
+	}
+	root, removed := m.root.without(maphash.Comparable(seed, k), k, 0)
+	if !removed {
+		return m
+	}
+	return Map[K, V]{root, m.size - 1}
+}
+
+// Seq2 returns an [iter.Seq2][K,V] over the keys and values of the map.
+func (m Map[K, V]) Seq2() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if m.root != nil {
+			m.root.all(yield)
+		}
+	}
+}
+
+// Iter returns an [iterator.Iterator2][K,V] over the keys and values of the
+// map.
+func (m Map[K, V]) Iter() iterator.Iterator2[K, V] {
+	remain := m.size
+	return iterator.New2WithSize(
+		func(yield func(K, V) bool) {
+			remain = m.size
+			m.Seq2()(func(k K, v V) bool {
+				remain--
+				return yield(k, v)
+			})
+		},
+		func() iterator.IteratorSize {
+			return iterator.NewSize(remain)
+		},
+	)
+}
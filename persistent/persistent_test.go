@@ -0,0 +1,134 @@
+package persistent
+
+import (
+	"maps"
+	"math/rand"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/robdavid/genutil-go/iterator"
+	"github.com/robdavid/genutil-go/opt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestList(t *testing.T) {
+	lst := ListOf(1, 2, 3)
+	assert.Equal(t, 3, lst.Len())
+	assert.Equal(t, []int{1, 2, 3}, lst.Iter().Collect())
+	longer := lst.Prepend(0)
+	assert.Equal(t, []int{0, 1, 2, 3}, longer.Iter().Collect())
+	assert.Equal(t, []int{1, 2, 3}, lst.Iter().Collect())
+	assert.Same(t, lst.head, longer.Tail().head)
+	assert.Equal(t, opt.Value(0), longer.Head())
+	assert.Equal(t, []int{2, 3}, slices.Collect(lst.Tail().Seq()))
+}
+
+func TestListEmpty(t *testing.T) {
+	var lst List[string]
+	assert.True(t, lst.IsEmpty())
+	assert.Zero(t, lst.Len())
+	assert.True(t, lst.Head().IsEmpty())
+	assert.True(t, lst.Tail().IsEmpty())
+	assert.Empty(t, lst.Iter().Collect())
+}
+
+func TestListFrom(t *testing.T) {
+	assert.Equal(t, []int{0, 1, 2, 3}, ListFromSeq(iterator.Range(0, 4).Seq()).Iter().Collect())
+	itr := ListFrom(iterator.Of("a", "b")).Iter()
+	assert.True(t, itr.Size().IsKnownToBe(2))
+	assert.True(t, itr.Next())
+	assert.True(t, itr.Size().IsKnownToBe(1))
+}
+
+func TestMap(t *testing.T) {
+	var empty Map[string, int]
+	one := empty.With("one", 1)
+	two := one.With("two", 2)
+	assert.Equal(t, 0, empty.Len())
+	assert.Equal(t, 1, one.Len())
+	assert.Equal(t, 2, two.Len())
+	assert.Equal(t, 2, two.Get("two"))
+	_, ok := one.GetOk("two")
+	assert.False(t, ok)
+	replaced := two.With("one", 10)
+	assert.Equal(t, 2, replaced.Len())
+	assert.Equal(t, 10, replaced.Get("one"))
+	assert.Equal(t, 1, two.Get("one"))
+	assert.Equal(t, map[string]int{"two": 2}, iterator.CollectMap(two.Without("one").Iter()))
+	assert.Equal(t, map[string]int{"one": 1, "two": 2}, iterator.CollectMap(two.Iter()))
+	assert.Equal(t, two, two.Without("three"))
+	assert.True(t, one.Without("one").IsEmpty())
+	assert.True(t, empty.Without("one").IsEmpty())
+}
+
+func TestMapFrom(t *testing.T) {
+	input := map[string]int{"a": 1, "b": 2, "c": 3}
+	m := MapFromSeq(maps.All(input))
+	assert.Equal(t, input, maps.Collect(m.Seq2()))
+	itr := MapFrom(iterator.Map2(m.Iter(), func(k string, v int) (string, int) { return k, v * 2 })).Iter()
+	assert.True(t, itr.Size().IsKnownToBe(3))
+	assert.Equal(t, map[string]int{"a": 2, "b": 4, "c": 6}, iterator.CollectMap(itr))
+}
+
+func TestMapRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	expected := make(map[int]string)
+	var m Map[int, string]
+	var snapshots []Map[int, string]
+	var expectedSnapshots []map[int]string
+	for i := range 5000 {
+		k := rnd.Intn(1000)
+		if rnd.Intn(3) == 0 {
+			delete(expected, k)
+			m = m.Without(k)
+		} else {
+			expected[k] = strconv.Itoa(i)
+			m = m.With(k, strconv.Itoa(i))
+		}
+		if i%500 == 0 {
+			snapshots = append(snapshots, m)
+			expectedSnapshots = append(expectedSnapshots, maps.Clone(expected))
+		}
+	}
+	assert.Equal(t, len(expected), m.Len())
+	assert.Equal(t, expected, maps.Collect(m.Seq2()))
+	for i, snapshot := range snapshots {
+		assert.Equal(t, expectedSnapshots[i], maps.Collect(snapshot.Seq2()))
+		assert.Equal(t, len(expectedSnapshots[i]), snapshot.Len())
+	}
+	for k := range expected {
+		m = m.Without(k)
+	}
+	assert.True(t, m.IsEmpty())
+	assert.Nil(t, m.root)
+}
+
+func TestNodeCollisions(t *testing.T) {
+	const hash = 0xdeadbeef
+	root := &node[string, int]{}
+	for i, k := range []string{"a", "b", "c"} {
+		var added bool
+		root, added = root.with(entry[string, int]{hash: hash, key: k, value: i}, 0)
+		assert.True(t, added)
+	}
+	root, added := root.with(entry[string, int]{hash: hash, key: "b", value: 10}, 0)
+	assert.False(t, added)
+	for k, v := range map[string]int{"a": 0, "b": 10, "c": 2} {
+		actual, ok := root.get(hash, k)
+		assert.True(t, ok)
+		assert.Equal(t, v, actual)
+	}
+	_, ok := root.get(hash, "d")
+	assert.False(t, ok)
+	root, removed := root.without(hash, "a", 0)
+	assert.True(t, removed)
+	root, removed = root.without(hash, "c", 0)
+	assert.True(t, removed)
+	assert.Len(t, root.entries, 1)
+	assert.Nil(t, root.entries[0].child)
+	assert.Equal(t, "b", root.entries[0].key)
+	root, removed = root.without(hash, "b", 0)
+	assert.True(t, removed)
+	assert.Nil(t, root)
+}
@@ -3,11 +3,11 @@ package slices
 import (
 	"errors"
 	"fmt"
-	"runtime"
 	"sort"
 	"sync"
 
 	"github.com/robdavid/genutil-go/functions"
+	"github.com/robdavid/genutil-go/internal/paroptions"
 	"github.com/robdavid/genutil-go/internal/rangehelper"
 	"github.com/robdavid/genutil-go/iterator"
 	"github.com/robdavid/genutil-go/opt"
@@ -226,28 +226,19 @@ func IncRange[T ordered.Real](start, end T) []T {
 	return rangeBy(start, end, functions.IfElse(end < start, -1, 1), true, 0, 0)
 }
 
-type parOptions struct {
-	threshold int
-	maxCpu    int
-}
-
-type ParOption func(*parOptions)
-
-func combineParOptions(opts []ParOption) parOptions {
-	result := parOptions{100000, runtime.NumCPU()}
-	for _, opt := range opts {
-		opt(&result)
-	}
-	return result
-}
+// ParOption is an option that controls the parallel execution of the Par*
+// functions.
+type ParOption = paroptions.Option
 
 // ParThreshold is an option that defines the minimum size of a slice beyond which multiple goroutine
 // threads will be used to populate a slice in a Par*Range* function. Defaults to 100000.
-func ParThreshold(threshold int) ParOption { return func(o *parOptions) { o.threshold = threshold } }
+func ParThreshold(threshold int) ParOption {
+	return func(o *paroptions.Options) { o.Threshold = threshold }
+}
 
 // ParMaxCpu is the maximum number of goroutine threads to use to populate a slice range in parallel
 // in a Par*Range* function. Defaults to `runtime.MaxCPU()`.
-func ParMaxCpu(maxCpu int) ParOption { return func(o *parOptions) { o.maxCpu = maxCpu } }
+func ParMaxCpu(maxCpu int) ParOption { return func(o *paroptions.Options) { o.MaxCpu = maxCpu } }
 
 // ParRange generates a slice consisting of a sequence of real numbers, potentially using
 // multiple parallel go routines to accelerate the process on multi core systems.
@@ -270,8 +261,8 @@ func ParMaxCpu(maxCpu int) ParOption { return func(o *parOptions) { o.maxCpu = m
 //
 //	slices.ParRange(0, 400000, ParThreshold(100000), ParMaxCpu(4))
 func ParRange[T ordered.Real](start, end T, parOpts ...ParOption) []T {
-	opts := combineParOptions(parOpts)
-	return rangeBy(start, end, functions.IfElse(end < start, -1, 1), false, opts.threshold, opts.maxCpu)
+	opts := paroptions.Combine(parOpts)
+	return rangeBy(start, end, functions.IfElse(end < start, -1, 1), false, opts.Threshold, opts.MaxCpu)
 }
 
 // ParIncRange generates a slice consisting of a sequence of real numbers, potentially using
@@ -295,8 +286,8 @@ func ParRange[T ordered.Real](start, end T, parOpts ...ParOption) []T {
 //
 //	slices.ParIncRange(0, 400000, ParThreshold(100000), ParMaxCpu(4))
 func ParIncRange[T ordered.Real](start, end T, parOpts ...ParOption) []T {
-	opts := combineParOptions(parOpts)
-	return rangeBy(start, end, functions.IfElse(end < start, -1, 1), true, opts.threshold, opts.maxCpu)
+	opts := paroptions.Combine(parOpts)
+	return rangeBy(start, end, functions.IfElse(end < start, -1, 1), true, opts.Threshold, opts.MaxCpu)
 }
 
 // ParRangeBy generates a slice consisting of a sequence of real numbers, potentially using
@@ -327,8 +318,8 @@ func ParIncRange[T ordered.Real](start, end T, parOpts ...ParOption) []T {
 //
 //	slices.ParRangeBy(0, 400000, 1, ParThreshold(100000), ParMaxCpu(4))
 func ParRangeBy[T ordered.Real, S ordered.Real](start, end T, step S, parOpts ...ParOption) []T {
-	opts := combineParOptions(parOpts)
-	return rangeBy(start, end, step, false, opts.threshold, opts.maxCpu)
+	opts := paroptions.Combine(parOpts)
+	return rangeBy(start, end, step, false, opts.Threshold, opts.MaxCpu)
 }
 
 // ParIncRangeBy generates a slice consisting of a sequence of real numbers, potentially using
@@ -359,8 +350,8 @@ func ParRangeBy[T ordered.Real, S ordered.Real](start, end T, step S, parOpts ..
 //
 //	slices.ParIncRangeBy(0, 400000, 1, ParThreshold(100000), ParMaxCpu(4))
 func ParIncRangeBy[T ordered.Real, S ordered.Real](start, end T, step S, parOpts ...ParOption) []T {
-	opts := combineParOptions(parOpts)
-	return rangeBy(start, end, step, true, opts.threshold, opts.maxCpu)
+	opts := paroptions.Combine(parOpts)
+	return rangeBy(start, end, step, true, opts.Threshold, opts.MaxCpu)
 }
 
 // Returns true if predicate returns true for all elements in